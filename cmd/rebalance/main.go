@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/camunda"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/controller"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/processio"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/shards"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/shards/cache"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/vid"
+)
+
+func main() {
+	configLocation := flag.String("config", "config.json", "configuration file")
+	target := flag.String("target", "", "target shard url; defaults to the shard with the fewest users")
+	instanceHandling := flag.String("instances", model.InstanceHandlingDrain, "handling of running process-instances in the source shard (drain or cancel)")
+	drainTimeout := flag.String("drain-timeout", "", "max duration to wait for running process-instances to finish (e.g. 10m)")
+	noConfirmation := flag.Bool("no-confirmation", false, "dont confirm rebalance operation")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		log.Fatal("expect user ids as arguments")
+	}
+
+	configuration.LogEnvConfig = false
+	config, err := configuration.LoadConfig(*configLocation)
+	if err != nil {
+		log.Fatal("unable to load config", err)
+	}
+
+	fmt.Println("this will move", len(args), "users to another shard and", *instanceHandling, "their running process-instances")
+	if !*noConfirmation {
+		reader := bufio.NewReader(os.Stdin)
+		fmt.Print("continue? (y/n): ")
+		in, err := reader.ReadString('\n')
+		if err != nil {
+			log.Fatal(err)
+		}
+		if strings.TrimSpace(in) != "y" && strings.TrimSpace(in) != "Y" {
+			return
+		}
+	}
+
+	ctrl, err := createController(config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, userId := range args {
+		result, err, _ := ctrl.Rebalance(model.RebalanceRequest{
+			UserId:           userId,
+			TargetShard:      *target,
+			InstanceHandling: *instanceHandling,
+			DrainTimeout:     *drainTimeout,
+		})
+		if err != nil {
+			log.Fatal("unable to rebalance ", userId, ": ", err)
+		}
+		out, _ := json.Marshal(result)
+		fmt.Println(string(out))
+	}
+}
+
+func createController(config configuration.Config) (ctrl *controller.Controller, err error) {
+	v, err := vid.New(config.WrapperDb)
+	if err != nil {
+		return ctrl, err
+	}
+	s, err := shards.New(config.ShardingDb, cache.None)
+	if err != nil {
+		return ctrl, err
+	}
+	processIo := processio.NewOrNil(config)
+	c := camunda.New(config, v, s, processIo)
+	return controller.New(config, c, v, processIo), nil
+}
@@ -26,7 +26,10 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "deploy process, meant for internal use by the process-deployment service, only admins may access this endpoint",
+                "description": "deploy process, meant for internal use by the process-deployment service, only admins may access this endpoint\nwith async=true, the deployment is checked and queued; the response contains the deployment job, which may be polled at /v2/deployment-jobs/{id}\ndeployments with an id of a queued or running deployment job are rejected with 409\nwhile the deployments of the user are moved to another shard, deployments are rejected with 503",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "deployment"
                 ],
@@ -40,12 +43,30 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.DeploymentMessage"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "queue deployment and return the deployment job",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "url the finished deployment job is posted to; only used with async=true",
+                        "name": "callback_url",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK"
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.DeploymentJob"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request"
                     },
@@ -58,8 +79,14 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found"
                     },
+                    "409": {
+                        "description": "Conflict"
+                    },
                     "500": {
                         "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
                     }
                 }
             }
@@ -114,29 +141,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/deployments": {
-            "get": {
+        "/shards/rebalance": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "list deployments",
+                "description": "moves all deployments of a user to another shard and assigns the user to it, only admins may access this endpoint; 409 if a rebalance of the user is already running or process-instances are not drained",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "deployment"
+                    "shards"
+                ],
+                "summary": "rebalance user",
+                "parameters": [
+                    {
+                        "description": "rebalance request",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RebalanceRequest"
+                        }
+                    }
                 ],
-                "summary": "list deployments",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.ExtendedDeployment"
-                            }
+                            "$ref": "#/definitions/model.RebalanceResult"
                         }
                     },
                     "400": {
@@ -151,31 +186,34 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found"
                     },
+                    "409": {
+                        "description": "Conflict"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/v2/deployments/{id}": {
+        "/v2/deployment-jobs/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "get process deployment by id",
+                "description": "returns the progress of a deployment; the step is the last finished step; failed jobs contain the error",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "deployment"
                 ],
-                "summary": "get deployment",
+                "summary": "get deployment job",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "deployment id",
+                        "description": "deployment job id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -185,7 +223,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.CamundaDeployment"
+                            "$ref": "#/definitions/model.DeploymentJob"
                         }
                     },
                     "400": {
@@ -206,36 +244,83 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/deployments/{id}/definition": {
+        "/v2/deployments": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "get deployment process-definition",
+                "description": "list deployments",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "deployment",
-                    "process-definition"
+                    "deployment"
                 ],
-                "summary": "get deployment process-definition",
+                "summary": "list deployments",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ExtendedDeployment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/deployments/validate": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "dry run of a deployment: checks the bpmn like a deployment would, without deploying it to a shard; returns errors and warnings with their position in the xml",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deployment"
+                ],
+                "summary": "validate deployment",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "deployment id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "deployment",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Deployment"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ProcessDefinition"
+                            "$ref": "#/definitions/model.DeploymentValidationResult"
                         }
                     },
                     "400": {
@@ -247,30 +332,27 @@ const docTemplate = `{
                     "403": {
                         "description": "Forbidden"
                     },
-                    "404": {
-                        "description": "Not Found"
-                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/v2/deployments/{id}/exists": {
+        "/v2/deployments/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "deployment exists",
+                "description": "get process deployment by id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "deployment"
                 ],
-                "summary": "deployment exists",
+                "summary": "get deployment",
                 "parameters": [
                     {
                         "type": "string",
@@ -284,7 +366,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "boolean"
+                            "$ref": "#/definitions/model.CamundaDeployment"
                         }
                     },
                     "400": {
@@ -305,22 +387,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/deployments/{id}/instances": {
+        "/v2/deployments/{id}/definition": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "get deployment process-instances",
+                "description": "get deployment process-definition",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "deployment",
-                    "process-instance"
+                    "process-definition"
                 ],
-                "summary": "get deployment process-instances",
+                "summary": "get deployment process-definition",
                 "parameters": [
                     {
                         "type": "string",
@@ -334,10 +416,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.ProcessInstance"
-                            }
+                            "$ref": "#/definitions/model.ProcessDefinition"
                         }
                     },
                     "400": {
@@ -358,22 +437,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/deployments/{id}/parameter": {
+        "/v2/deployments/{id}/exists": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "get deployment start parameter",
+                "description": "deployment exists",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "start",
                     "deployment"
                 ],
-                "summary": "get deployment parameter",
+                "summary": "deployment exists",
                 "parameters": [
                     {
                         "type": "string",
@@ -387,7 +465,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.VariableMap"
+                            "type": "boolean"
                         }
                     },
                     "400": {
@@ -408,22 +486,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/deployments/{id}/start": {
+        "/v2/deployments/{id}/incident-handling": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "start deployment by id",
+                "description": "get the incident handling last set for the deployment by a deployment or by PUT /v2/deployments/{id}/incident-handling; older deployments use the handler registered at the process-incident-api; 404 if none is set",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "start",
-                    "deployment"
+                    "deployment",
+                    "incidents"
                 ],
-                "summary": "start deployment",
+                "summary": "get deployment incident handling",
                 "parameters": [
                     {
                         "type": "string",
@@ -431,19 +509,13 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "businessKey of started process",
-                        "name": "business_key",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ProcessInstance"
+                            "$ref": "#/definitions/model.IncidentHandling"
                         }
                     },
                     "400": {
@@ -452,9 +524,6 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
-                    "403": {
-                        "description": "Forbidden"
-                    },
                     "404": {
                         "description": "Not Found"
                     },
@@ -462,31 +531,40 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            }
-        },
-        "/v2/event-trigger": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "trigger event",
+                "description": "registers the incident handling (restart, notify) for the process-definitions of the active deployment version without redeployment; running process-instances are kept and their incidents are handled with the new settings",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "event"
+                    "deployment",
+                    "incidents"
                 ],
-                "summary": "trigger event",
+                "summary": "set deployment incident handling",
                 "parameters": [
                     {
-                        "description": "ref https://docs.camunda.org/rest/camunda-bpm-platform/7.23-SNAPSHOT/#tag/Message/operation/deliverMessage",
+                        "type": "string",
+                        "description": "deployment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "incident handling",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.EventTriggerMessage"
+                            "$ref": "#/definitions/model.IncidentHandling"
                         }
                     }
                 ],
@@ -500,9 +578,6 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
-                    "403": {
-                        "description": "Forbidden"
-                    },
                     "404": {
                         "description": "Not Found"
                     },
@@ -512,27 +587,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/history/process-instances": {
+        "/v2/deployments/{id}/instances": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "list historic process-instances",
+                "description": "get deployment process-instances",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
+                    "deployment",
                     "process-instance"
                 ],
-                "summary": "list historic process-instances",
+                "summary": "get deployment process-instances",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "if set to true, wraps the result in an objet with the result {total:0, data:[]}",
-                        "name": "with_total",
-                        "in": "query"
+                        "type": "string",
+                        "description": "deployment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -541,7 +618,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.HistoricProcessInstance"
+                                "$ref": "#/definitions/model.ProcessInstance"
                             }
                         }
                     },
@@ -561,61 +638,78 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v2/deployments/{id}/parameter": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "delete multiple historic process-instances",
+                "description": "get deployment start parameter",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "process-instance"
+                    "start",
+                    "deployment"
                 ],
-                "summary": "delete multiple historic process-instances",
+                "summary": "get deployment parameter",
                 "parameters": [
                     {
-                        "description": "ids",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string",
+                        "description": "deployment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.VariableMap"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/v2/history/process-instances/{id}": {
-            "delete": {
+        "/v2/deployments/{id}/parameter-schema": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "delete historic process-instance",
+                "description": "get json schema of the deployment start parameter, generated from the camunda:formField elements of the start event; start endpoints validate their inputs with this schema, unless start_parameter_validation is disabled",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "process-instance"
+                    "start",
+                    "deployment"
                 ],
-                "summary": "delete historic process-instance",
+                "summary": "get deployment parameter schema",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "process-instance id",
+                        "description": "deployment id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -623,47 +717,63 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
-                    },
-                    "400": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.JsonSchema"
+                        }
+                    },
+                    "400": {
                         "description": "Bad Request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/v2/process-definitions/{id}": {
-            "get": {
+        "/v2/deployments/{id}/rollback": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "get process-definition",
+                "description": "deploys a previous version of the deployment again as new active version with the stored incident handling; new process-instances use the new version, running process-instances are not changed",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "process-definition"
+                    "deployment"
                 ],
-                "summary": "get process-definition",
+                "summary": "rollback deployment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "process-definition id",
+                        "description": "deployment id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "version to deploy again",
+                        "name": "version",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.ProcessDefinition"
-                        }
+                        "description": "OK"
                     },
                     "400": {
                         "description": "Bad Request"
@@ -677,31 +787,36 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found"
                     },
+                    "409": {
+                        "description": "Conflict"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/v2/process-definitions/{id}/diagram": {
+        "/v2/deployments/{id}/schedules": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "get process-definition diagram",
+                "description": "lists the start schedules of a deployment with the status of their last run",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "process-definition"
+                    "start",
+                    "deployment",
+                    "schedule"
                 ],
-                "summary": "get process-definition diagram",
+                "summary": "list deployment start schedules",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "process-definition id",
+                        "description": "deployment id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -711,7 +826,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StartSchedule"
+                            }
                         }
                     },
                     "400": {
@@ -730,42 +848,50 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            }
-        },
-        "/v2/process-definitions/{id}/start": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "start process-definitions by id",
+                "description": "starts a process-instance of the deployment at every match of the cron expression in the time zone of the schedule (default UTC); the business_key may be a go template with the fields .ScheduleId, .DeploymentId and .Time; inputs are validated with the start parameter schema on every run",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "start",
-                    "process-definitions"
+                    "deployment",
+                    "schedule"
                 ],
-                "summary": "start process-definitions",
+                "summary": "create deployment start schedule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "process-definitions id",
+                        "description": "deployment id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "businessKey of started process",
-                        "name": "business_key",
-                        "in": "query"
+                        "description": "schedule; id, deployment_id and run status are ignored",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StartSchedule"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StartSchedule"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
@@ -785,26 +911,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/process-definitions/{id}/start/id": {
+        "/v2/deployments/{id}/start": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "start process-definitions and get started process-instance",
+                "description": "start deployment by id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "start",
-                    "process-definitions"
+                    "deployment"
                 ],
-                "summary": "start process-definitions and get instance",
+                "summary": "start deployment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "process-definitions id",
+                        "description": "deployment id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -814,6 +940,12 @@ const docTemplate = `{
                         "description": "businessKey of started process",
                         "name": "business_key",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "retries with the same key return the response of the first successful start",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -824,7 +956,10 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.StartParameterError"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized"
@@ -839,35 +974,61 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            }
-        },
-        "/v2/process-instances": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "list process-instances",
+                "description": "start deployment by id with business key and typed variables; variables are validated with the start parameter schema, unless start_parameter_validation is disabled",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "process-instance"
+                    "start",
+                    "deployment"
+                ],
+                "summary": "start deployment with json body",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "deployment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "start request",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StartRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "retries with the same key return the response of the first successful start",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
                 ],
-                "summary": "list process-instances",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.ProcessInstance"
-                            }
+                            "$ref": "#/definitions/model.ProcessInstanceWithVariables"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.StartParameterError"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized"
@@ -882,64 +1043,116 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v2/deployments/{id}/start-batch": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "delete multiple process-instances",
+                "description": "start many process-instances of a deployment with one request; every item is validated with the start parameter schema; the response lists the started instance or the error of every item in the order of the request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "process-instance"
+                    "start",
+                    "deployment"
                 ],
-                "summary": "delete multiple process-instances",
+                "summary": "start deployment batch",
                 "parameters": [
                     {
-                        "description": "ids",
+                        "type": "string",
+                        "description": "deployment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "retries with the same key return the response of the first successful start",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "items to start",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "string"
+                                "$ref": "#/definitions/model.BatchStartItem"
                             }
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.BatchStartResult"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/v2/process-instances-by-business-key/{business_key}": {
-            "delete": {
+        "/v2/deployments/{id}/suspended": {
+            "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "stops process-instances identified by business-key",
+                "description": "suspends or resumes the process-definitions of a deployment and all their process-instances; suspended deployments can not be started",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "process-instance"
+                    "deployment"
                 ],
-                "summary": "delete process-instances by business-key",
+                "summary": "suspend or resume deployment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "business-key of instances",
-                        "name": "business_key",
+                        "description": "deployment id",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "suspension state",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SuspensionState"
+                        }
                     }
                 ],
                 "responses": {
@@ -949,32 +1162,53 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/v2/process-instances/count": {
+        "/v2/deployments/{id}/versions": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "process-instance count",
+                "description": "lists the stored versions of a deployment, newest first; the count of kept inactive versions is configured by deployment_versions_kept",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "process-instance"
+                    "deployment"
+                ],
+                "summary": "list deployment versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "deployment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "process-instance count",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "integer"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.DeploymentVersion"
+                            }
                         }
                     },
                     "400": {
@@ -995,25 +1229,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/process-instances/{id}": {
-            "delete": {
+        "/v2/event-trigger": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "delete process-instance",
+                "description": "trigger event",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "process-instance"
+                    "event"
                 ],
-                "summary": "delete process-instance",
+                "summary": "trigger event",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "process-instance id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "ref https://docs.camunda.org/rest/camunda-bpm-platform/7.23-SNAPSHOT/#tag/Message/operation/deliverMessage",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.EventTriggerMessage"
+                        }
                     }
                 ],
                 "responses": {
@@ -1023,45 +1262,164 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/v2/process-instances/{id}/variables/{variable_name}": {
-            "put": {
+        "/v2/external-tasks/fetch-and-lock": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "set process-instance variable",
+                "description": "locks external tasks of the given topics for the worker; only tasks of the user's process-instances are returned. asyncResponseTimeout (ms) enables long polling, the request is answered as soon as tasks are available or the timeout is reached; the timeout is limited by the wrapper configuration.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "process-instance"
+                    "external-tasks"
                 ],
-                "summary": "set process-instance variable",
+                "summary": "fetch and lock external tasks",
+                "parameters": [
+                    {
+                        "description": "fetch request",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ExternalTaskFetchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.LockedExternalTask"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/external-tasks/{id}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "completes an external task locked by the worker and sets the given variables",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "external-tasks"
+                ],
+                "summary": "complete external task",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "process-instance id",
+                        "description": "external task id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "description": "complete request",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ExternalTaskCompleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/external-tasks/{id}/extend-lock": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "sets the lock of an external task locked by the worker to expire newDuration (ms) from now",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "external-tasks"
+                ],
+                "summary": "extend external task lock",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "variable_name",
-                        "name": "variable_name",
+                        "description": "external task id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "value",
+                        "description": "extend lock request",
                         "name": "message",
                         "in": "body",
                         "required": true,
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/model.ExternalTaskExtendLockRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1085,269 +1443,3044 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "api.EventTriggerMessage": {
-            "type": "object",
-            "properties": {
-                "messageName": {
-                    "type": "string"
-                },
-                "processVariablesLocal": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "object",
-                        "properties": {
-                            "type": {
+        },
+        "/v2/external-tasks/{id}/failure": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "reports the failure of an external task locked by the worker; the task is retried after retryTimeout (ms) while retries are left, otherwise an incident is created",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "external-tasks"
+                ],
+                "summary": "report external task failure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "external task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "failure",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ExternalTaskFailureRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/history/process-instances": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "list historic process-instances",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "list historic process-instances",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "if set to true, wraps the result in an objet with the result {total:0, data:[]}",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.HistoricProcessInstance"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "delete multiple historic process-instances",
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "delete multiple historic process-instances",
+                "parameters": [
+                    {
+                        "description": "ids",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
                                 "type": "string"
-                            },
-                            "value": {}
+                            }
                         }
                     }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/history/process-instances/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "delete historic process-instance",
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "delete historic process-instance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-instance id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/history/process-instances/{id}/activities": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get all started activities of a running or finished process-instance with start and end times, sorted by start time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "get historic process-instance activities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-instance id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.HistoricActivityInstance"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/history/process-instances/{id}/restart": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "starts a new process-instance of the same process-definition with the initial variables of a finished process-instance; by default at the start activity of the finished instance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "restart historic process-instance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "historic process-instance id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "restart options",
+                        "name": "message",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.RestartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/incidents": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "list open incidents of the user's process-instances, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "list incidents",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "max count of returned incidents",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "count of skipped incidents",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by process-instance id",
+                        "name": "process_instance_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by process-definition id",
+                        "name": "definition_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by deployment id",
+                        "name": "deployment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by incident type (e.g. failedJob, failedExternalTask)",
+                        "name": "incident_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Incident"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/incidents/{id}/resolve": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "retries the failed job or external task of the incident once; the engine removes the incident with the retry and creates a new one if the retry fails. other incident types are rejected with 400.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "resolve incident",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "incident id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/process-definitions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get process-definition",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-definition"
+                ],
+                "summary": "get process-definition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-definition id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProcessDefinition"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/process-definitions/{id}/diagram": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get process-definition diagram",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-definition"
+                ],
+                "summary": "get process-definition diagram",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-definition id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/process-definitions/{id}/start": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "start process-definitions by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "start",
+                    "process-definitions"
+                ],
+                "summary": "start process-definitions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-definitions id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "businessKey of started process",
+                        "name": "business_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "retries with the same key return the response of the first successful start",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.StartParameterError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "start process-definition by id with business key and typed variables; variables are validated with the start parameter schema, unless start_parameter_validation is disabled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "start",
+                    "process-definitions"
+                ],
+                "summary": "start process-definition with json body",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-definitions id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "start request",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StartRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "retries with the same key return the response of the first successful start",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProcessInstanceWithVariables"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.StartParameterError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/process-definitions/{id}/start/id": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "start process-definitions and get started process-instance",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "start",
+                    "process-definitions"
+                ],
+                "summary": "start process-definitions and get instance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-definitions id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "businessKey of started process",
+                        "name": "business_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "retries with the same key return the response of the first successful start",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProcessInstance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.StartParameterError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/process-instances": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "list process-instances",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "list process-instances",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "max count of returned process-instances",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "count of skipped process-instances",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort field and direction; fields: id, definition_id, definition_key, business_key; directions: asc, desc; e.g. business_key.desc",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by business key",
+                        "name": "business_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by process-definition id",
+                        "name": "definition_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by deployment id",
+                        "name": "deployment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "filter by suspension state",
+                        "name": "suspended",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "if set to true, wraps the result in an objet with the result {total:0, data:[]}",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ProcessInstance"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "delete multiple process-instances",
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "delete multiple process-instances",
+                "parameters": [
+                    {
+                        "description": "ids",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/process-instances-by-business-key/{business_key}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "stops process-instances identified by business-key",
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "delete process-instances by business-key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "business-key of instances",
+                        "name": "business_key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/process-instances/count": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "process-instance count",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "process-instance count",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/process-instances/events": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "server-sent events of the process-instances of the user: started and deleted by the wrapper, finished and incidents from the engine history (polled every instance_event_poll_interval); the sse event name is the event type; events are dropped if the client does not keep up; started and deleted events are only sent to streams of the wrapper instance handling the start or delete request, so with multiple replicas a stream may miss them",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "process-instance event stream",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "filter by deployment id",
+                        "name": "deployment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated list of event types: started, finished, deleted, incident; default all",
+                        "name": "types",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProcessInstanceEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/process-instances/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "delete process-instance",
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "delete process-instance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-instance id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/process-instances/{id}/activities": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get the activity instance tree of a running process-instance; the leaves are the currently active activities (activityId references the bpmn element in the process-definition diagram)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "get process-instance activities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-instance id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ActivityInstance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/process-instances/{id}/incidents": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get the open incidents of a running process-instance, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-instance",
+                    "incidents"
+                ],
+                "summary": "get process-instance incidents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-instance id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Incident"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/process-instances/{id}/modification": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "starts and cancels activities of a running process-instance (e.g. to repeat a failed task or to skip a task); instruction types: startBeforeActivity, startAfterActivity, startTransition, cancel",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "modify process-instance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-instance id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "modification",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProcessInstanceModification"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/process-instances/{id}/suspended": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "suspends or resumes a process-instance; suspended instances keep their state but are not executed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "suspend or resume process-instance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-instance id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "suspension state",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SuspensionState"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/process-instances/{id}/variables": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get the current variables of a process-instance; Json values and Object values serialized as json are returned parsed, Date values as RFC3339",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "get process-instance variables",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-instance id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.VariableMap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "sets and deletes multiple variables of a process-instance in one engine call; untyped objects and lists are stored as Json, values of type Json and Object (serializationDataFormat application/json) are passed as json values (strings are stored as json strings), Date values may be passed as RFC3339; variables returned by GET may be sent back unchanged",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "update process-instance variables",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-instance id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "modifications and deletions",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.VariableModifications"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/process-instances/{id}/variables/{variable_name}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get a current variable of a process-instance; Json values and Object values serialized as json are returned parsed, Date values as RFC3339",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "get process-instance variable",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-instance id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "variable name",
+                        "name": "variable_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Variable"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "set process-instance variable",
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "set process-instance variable",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "process-instance id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "variable_name",
+                        "name": "variable_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "value",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {}
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/schedules/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get start schedule with the status of its last run",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "start",
+                    "schedule"
+                ],
+                "summary": "get start schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "schedule id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StartSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "replaces cron, time_zone, inputs, business_key and enabled of the schedule; the next run is recalculated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "start",
+                    "schedule"
+                ],
+                "summary": "update start schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "schedule id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "schedule; id, deployment_id and run status are ignored",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StartSchedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StartSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "delete start schedule",
+                "tags": [
+                    "start",
+                    "schedule"
+                ],
+                "summary": "delete start schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "schedule id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "lists the webhooks of the user; secrets are not included",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "list webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Webhook"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "the webhook receives signed json payloads (model.WebhookPayload) of process-instance events matching all set filters; the response contains the secret used for the signature, which is generated if not set",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "create webhook",
+                "parameters": [
+                    {
+                        "description": "webhook; id is ignored",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Webhook"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get webhook; the secret is not included",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "get webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "replaces the webhook; an empty secret keeps the current secret",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "update webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "webhook; id is ignored",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Webhook"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "deletes the webhook with its pending deliveries and delivery log",
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "delete webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v2/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "delivery log of the webhook, newest first; contains pending deliveries (retry queue) and delivered or failed deliveries kept for webhook_delivery_retention",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "list webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max count of returned deliveries; default 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "count of skipped deliveries",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.EventTriggerMessage": {
+            "type": "object",
+            "properties": {
+                "messageName": {
+                    "type": "string"
+                },
+                "processVariablesLocal": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "properties": {
+                            "type": {
+                                "type": "string"
+                            },
+                            "value": {}
+                        }
+                    }
+                },
+                "resultEnabled": {
+                    "type": "boolean"
+                },
+                "tenantId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ActivityInstance": {
+            "type": "object",
+            "properties": {
+                "activityId": {
+                    "type": "string"
+                },
+                "activityName": {
+                    "type": "string"
+                },
+                "activityType": {
+                    "type": "string"
+                },
+                "childActivityInstances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ActivityInstance"
+                    }
+                },
+                "childTransitionInstances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TransitionInstance"
+                    }
+                },
+                "executionIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "incidentIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "parentActivityInstanceId": {
+                    "type": "string"
+                },
+                "processDefinitionId": {
+                    "type": "string"
+                },
+                "processInstanceId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BatchStartItem": {
+            "type": "object",
+            "properties": {
+                "business_key": {
+                    "type": "string"
+                },
+                "inputs": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "model.BatchStartResult": {
+            "type": "object",
+            "properties": {
+                "business_key": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fields": {
+                    "description": "invalid inputs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FieldError"
+                    }
+                },
+                "instance": {
+                    "$ref": "#/definitions/model.ProcessInstance"
+                }
+            }
+        },
+        "model.CamundaDeployment": {
+            "type": "object",
+            "properties": {
+                "deploymentTime": {},
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "tenantId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Deployment": {
+            "type": "object",
+            "properties": {
+                "diagram": {
+                    "$ref": "#/definitions/model.Diagram"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "incident_handling": {
+                    "$ref": "#/definitions/model.IncidentHandling"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DeploymentJob": {
+            "type": "object",
+            "properties": {
+                "callback_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deployment_id": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "step": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DeploymentMessage": {
+            "type": "object",
+            "properties": {
+                "diagram": {
+                    "$ref": "#/definitions/model.Diagram"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "incident_handling": {
+                    "$ref": "#/definitions/model.IncidentHandling"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "optional",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DeploymentValidationResult": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ValidationMessage"
+                    }
+                },
+                "valid": {
+                    "type": "boolean"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ValidationMessage"
+                    }
+                }
+            }
+        },
+        "model.DeploymentVersion": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Diagram": {
+            "type": "object",
+            "properties": {
+                "svg": {
+                    "type": "string"
+                },
+                "xml_deployed": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ExtendedDeployment": {
+            "type": "object",
+            "properties": {
+                "definition_id": {
+                    "type": "string"
+                },
+                "deploymentTime": {},
+                "diagram": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "tenantId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ExternalTaskCompleteRequest": {
+            "type": "object",
+            "properties": {
+                "localVariables": {
+                    "$ref": "#/definitions/model.VariableMap"
+                },
+                "variables": {
+                    "$ref": "#/definitions/model.VariableMap"
+                },
+                "workerId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ExternalTaskExtendLockRequest": {
+            "type": "object",
+            "properties": {
+                "newDuration": {
+                    "description": "ms",
+                    "type": "integer"
+                },
+                "workerId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ExternalTaskFailureRequest": {
+            "type": "object",
+            "properties": {
+                "errorDetails": {
+                    "type": "string"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "retries": {
+                    "type": "integer"
+                },
+                "retryTimeout": {
+                    "description": "ms",
+                    "type": "integer"
+                },
+                "workerId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ExternalTaskFetchRequest": {
+            "type": "object",
+            "properties": {
+                "asyncResponseTimeout": {
+                    "description": "long polling timeout in ms",
+                    "type": "integer"
+                },
+                "maxTasks": {
+                    "type": "integer"
+                },
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ExternalTaskTopic"
+                    }
+                },
+                "usePriority": {
+                    "type": "boolean"
+                },
+                "workerId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ExternalTaskTopic": {
+            "type": "object",
+            "properties": {
+                "businessKey": {
+                    "type": "string"
+                },
+                "deserializeValues": {
+                    "type": "boolean"
+                },
+                "localVariables": {
+                    "type": "boolean"
+                },
+                "lockDuration": {
+                    "description": "ms",
+                    "type": "integer"
+                },
+                "processDefinitionId": {
+                    "type": "string"
+                },
+                "processDefinitionKey": {
+                    "type": "string"
+                },
+                "topicName": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.HistoricActivityInstance": {
+            "type": "object",
+            "properties": {
+                "activityId": {
+                    "type": "string"
+                },
+                "activityName": {
+                    "type": "string"
+                },
+                "activityType": {
+                    "type": "string"
+                },
+                "calledProcessInstanceId": {
+                    "type": "string"
+                },
+                "canceled": {
+                    "type": "boolean"
+                },
+                "completeScope": {
+                    "type": "boolean"
+                },
+                "durationInMillis": {
+                    "type": "number"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "executionId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "parentActivityInstanceId": {
+                    "type": "string"
+                },
+                "processDefinitionId": {
+                    "type": "string"
+                },
+                "processDefinitionKey": {
+                    "type": "string"
+                },
+                "processInstanceId": {
+                    "type": "string"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "taskId": {
+                    "type": "string"
+                },
+                "tenantId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.HistoricProcessInstance": {
+            "type": "object",
+            "properties": {
+                "businessKey": {
+                    "type": "string"
+                },
+                "caseInstanceId": {
+                    "type": "string"
+                },
+                "deleteReason": {
+                    "type": "string"
+                },
+                "durationInMillis": {
+                    "type": "number"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "processDefinitionId": {
+                    "type": "string"
+                },
+                "processDefinitionKey": {
+                    "type": "string"
+                },
+                "processDefinitionName": {
+                    "type": "string"
+                },
+                "processDefinitionVersion": {
+                    "type": "number"
+                },
+                "startActivityId": {
+                    "type": "string"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "startUserId": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "superCaseInstanceId": {
+                    "type": "string"
+                },
+                "superProcessInstanceId": {
+                    "type": "string"
+                },
+                "tenantId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Incident": {
+            "type": "object",
+            "properties": {
+                "activityId": {
+                    "type": "string"
+                },
+                "annotation": {
+                    "type": "string"
+                },
+                "causeIncidentId": {
+                    "type": "string"
+                },
+                "configuration": {
+                    "description": "id of the failed job or external task",
+                    "type": "string"
+                },
+                "executionId": {
+                    "type": "string"
+                },
+                "failedActivityId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "incidentMessage": {
+                    "type": "string"
+                },
+                "incidentTimestamp": {
+                    "type": "string"
+                },
+                "incidentType": {
+                    "type": "string"
+                },
+                "jobDefinitionId": {
+                    "type": "string"
+                },
+                "processDefinitionId": {
+                    "type": "string"
+                },
+                "processInstanceId": {
+                    "type": "string"
+                },
+                "rootCauseIncidentId": {
+                    "type": "string"
+                },
+                "tenantId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.IncidentHandling": {
+            "type": "object",
+            "properties": {
+                "notify": {
+                    "type": "boolean"
+                },
+                "restart": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.JsonSchema": {
+            "type": "object",
+            "properties": {
+                "$schema": {
+                    "type": "string"
+                },
+                "default": {},
+                "enum": {
+                    "type": "array",
+                    "items": {}
+                },
+                "maxLength": {
+                    "type": "integer"
+                },
+                "maximum": {
+                    "type": "number"
+                },
+                "minLength": {
+                    "type": "integer"
+                },
+                "minimum": {
+                    "type": "number"
+                },
+                "properties": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.JsonSchema"
+                    }
+                },
+                "readOnly": {
+                    "type": "boolean"
+                },
+                "required": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "x-enum-labels": {
+                    "description": "label by enum value",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.LockedExternalTask": {
+            "type": "object",
+            "properties": {
+                "activityId": {
+                    "type": "string"
+                },
+                "activityInstanceId": {
+                    "type": "string"
+                },
+                "businessKey": {
+                    "type": "string"
+                },
+                "errorDetails": {
+                    "type": "string"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "executionId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lockExpirationTime": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "processDefinitionId": {
+                    "type": "string"
+                },
+                "processDefinitionKey": {
+                    "type": "string"
+                },
+                "processInstanceId": {
+                    "type": "string"
+                },
+                "retries": {
+                    "type": "integer"
+                },
+                "tenantId": {
+                    "type": "string"
+                },
+                "topicName": {
+                    "type": "string"
+                },
+                "variables": {
+                    "$ref": "#/definitions/model.VariableMap"
+                },
+                "workerId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ModificationInstruction": {
+            "type": "object",
+            "properties": {
+                "activityId": {
+                    "type": "string"
+                },
+                "activityInstanceId": {
+                    "type": "string"
+                },
+                "ancestorActivityInstanceId": {
+                    "type": "string"
+                },
+                "transitionId": {
+                    "type": "string"
+                },
+                "transitionInstanceId": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "variables": {
+                    "$ref": "#/definitions/model.VariableMap"
+                }
+            }
+        },
+        "model.ProcessDefinition": {
+            "type": "object",
+            "properties": {
+                "Version": {
+                    "type": "integer"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "deploymentId": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "diagram": {
+                    "type": "string"
+                },
+                "historyTimeToLive": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
                 },
-                "resultEnabled": {
+                "name": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "suspended": {
                     "type": "boolean"
                 },
                 "tenantId": {
                     "type": "string"
+                },
+                "versionTag": {
+                    "type": "string"
                 }
             }
         },
-        "model.CamundaDeployment": {
+        "model.ProcessInstance": {
             "type": "object",
             "properties": {
-                "deploymentTime": {},
-                "id": {
+                "businessKey": {
                     "type": "string"
                 },
-                "name": {
+                "caseInstanceId": {
                     "type": "string"
                 },
-                "source": {
+                "definitionId": {
+                    "type": "string"
+                },
+                "ended": {
+                    "type": "boolean"
+                },
+                "id": {
                     "type": "string"
                 },
+                "suspended": {
+                    "type": "boolean"
+                },
                 "tenantId": {
                     "type": "string"
                 }
             }
         },
-        "model.DeploymentMessage": {
+        "model.ProcessInstanceEvent": {
             "type": "object",
             "properties": {
-                "diagram": {
-                    "$ref": "#/definitions/model.Diagram"
+                "business_key": {
+                    "type": "string"
                 },
-                "id": {
+                "deployment_id": {
+                    "description": "vid of the deployment, if known",
                     "type": "string"
                 },
-                "incident_handling": {
-                    "$ref": "#/definitions/model.IncidentHandling"
+                "incident_id": {
+                    "type": "string"
                 },
-                "name": {
+                "incident_message": {
                     "type": "string"
                 },
-                "source": {
-                    "description": "optional",
+                "process_definition_id": {
                     "type": "string"
                 },
-                "user_id": {
+                "process_instance_id": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "model.Diagram": {
+        "model.ProcessInstanceModification": {
             "type": "object",
             "properties": {
-                "svg": {
+                "annotation": {
                     "type": "string"
                 },
-                "xml_deployed": {
+                "instructions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ModificationInstruction"
+                    }
+                },
+                "skipCustomListeners": {
+                    "type": "boolean"
+                },
+                "skipIoMappings": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.ProcessInstanceWithVariables": {
+            "type": "object",
+            "properties": {
+                "businessKey": {
+                    "type": "string"
+                },
+                "caseInstanceId": {
                     "type": "string"
                 },
-                "xml_raw": {
+                "definitionId": {
+                    "type": "string"
+                },
+                "ended": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "suspended": {
+                    "type": "boolean"
+                },
+                "tenantId": {
                     "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.Variable"
+                    }
                 }
             }
         },
-        "model.ExtendedDeployment": {
+        "model.RebalanceRequest": {
             "type": "object",
             "properties": {
-                "definition_id": {
+                "drain_timeout": {
+                    "description": "optional; duration like \"10m\", defaults to no waiting",
                     "type": "string"
                 },
-                "deploymentTime": {},
-                "diagram": {
+                "instance_handling": {
+                    "description": "InstanceHandling decides what happens with running instances on the source shard\n\t\"drain\": wait up to DrainTimeout for running instances to finish (default)\n\t\"cancel\": delete running instances",
                     "type": "string"
                 },
-                "error": {
+                "target_shard": {
+                    "description": "optional; defaults to the shard with the fewest users",
                     "type": "string"
                 },
-                "id": {
+                "user_id": {
                     "type": "string"
+                }
+            }
+        },
+        "model.RebalanceResult": {
+            "type": "object",
+            "properties": {
+                "cancelled_instances": {
+                    "type": "integer"
                 },
-                "name": {
+                "cleanup_errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "moved_deployments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source_shard": {
                     "type": "string"
                 },
-                "source": {
+                "target_shard": {
                     "type": "string"
                 },
-                "tenantId": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "model.HistoricProcessInstance": {
+        "model.RestartRequest": {
             "type": "object",
             "properties": {
-                "businessKey": {
+                "skipCustomListeners": {
+                    "type": "boolean"
+                },
+                "skipIoMappings": {
+                    "type": "boolean"
+                },
+                "startActivityId": {
+                    "description": "defaults to the start activity of the historic process-instance",
                     "type": "string"
                 },
-                "caseInstanceId": {
+                "withoutBusinessKey": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.StartParameterError": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StartRequest": {
+            "type": "object",
+            "properties": {
+                "business_key": {
                     "type": "string"
                 },
-                "deleteReason": {
+                "idempotency_key": {
+                    "description": "alternative to the Idempotency-Key header",
                     "type": "string"
                 },
-                "durationInMillis": {
-                    "type": "number"
+                "variables": {
+                    "description": "type and valueInfo are optional",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.Variable"
+                    }
                 },
-                "endTime": {
+                "with_variables_in_return": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.StartSchedule": {
+            "type": "object",
+            "properties": {
+                "business_key": {
                     "type": "string"
                 },
-                "id": {
+                "created_at": {
                     "type": "string"
                 },
-                "processDefinitionId": {
+                "cron": {
                     "type": "string"
                 },
-                "processDefinitionKey": {
+                "deployment_id": {
                     "type": "string"
                 },
-                "processDefinitionName": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
                     "type": "string"
                 },
-                "processDefinitionVersion": {
-                    "type": "number"
+                "inputs": {
+                    "type": "object",
+                    "additionalProperties": true
                 },
-                "startActivityId": {
+                "last_error": {
                     "type": "string"
                 },
-                "startTime": {
+                "last_instance_id": {
                     "type": "string"
                 },
-                "startUserId": {
+                "last_run": {
                     "type": "string"
                 },
-                "state": {
+                "last_status": {
                     "type": "string"
                 },
-                "superCaseInstanceId": {
+                "next_run": {
                     "type": "string"
                 },
-                "superProcessInstanceId": {
+                "time_zone": {
+                    "description": "IANA time zone (e.g. Europe/Berlin); default UTC",
                     "type": "string"
                 },
-                "tenantId": {
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "model.IncidentHandling": {
+        "model.SuspensionState": {
             "type": "object",
             "properties": {
-                "notify": {
-                    "type": "boolean"
-                },
-                "restart": {
+                "suspended": {
                     "type": "boolean"
                 }
             }
         },
-        "model.ProcessDefinition": {
+        "model.TransitionInstance": {
             "type": "object",
             "properties": {
-                "Version": {
-                    "type": "integer"
+                "activityId": {
+                    "type": "string"
                 },
-                "category": {
+                "activityName": {
                     "type": "string"
                 },
-                "deploymentId": {
+                "activityType": {
                     "type": "string"
                 },
-                "description": {
+                "executionId": {
                     "type": "string"
                 },
-                "diagram": {
+                "id": {
                     "type": "string"
                 },
-                "historyTimeToLive": {
-                    "type": "integer"
+                "incidentIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "id": {
+                "parentActivityInstanceId": {
                     "type": "string"
                 },
-                "key": {
+                "processDefinitionId": {
                     "type": "string"
                 },
-                "name": {
+                "processInstanceId": {
                     "type": "string"
+                }
+            }
+        },
+        "model.ValidationMessage": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "integer"
                 },
-                "resource": {
+                "element_id": {
                     "type": "string"
                 },
-                "suspended": {
-                    "type": "boolean"
+                "line": {
+                    "type": "integer"
                 },
-                "tenantId": {
+                "message": {
                     "type": "string"
-                },
-                "versionTag": {
+                }
+            }
+        },
+        "model.Variable": {
+            "type": "object",
+            "properties": {
+                "type": {
                     "type": "string"
+                },
+                "value": {},
+                "valueInfo": {}
+            }
+        },
+        "model.VariableMap": {
+            "type": "object",
+            "additionalProperties": {
+                "$ref": "#/definitions/model.Variable"
+            }
+        },
+        "model.VariableModifications": {
+            "type": "object",
+            "properties": {
+                "deletions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "modifications": {
+                    "$ref": "#/definitions/model.VariableMap"
                 }
             }
         },
-        "model.ProcessInstance": {
+        "model.Webhook": {
             "type": "object",
             "properties": {
-                "businessKey": {
+                "business_key": {
                     "type": "string"
                 },
-                "caseInstanceId": {
+                "created_at": {
                     "type": "string"
                 },
-                "definitionId": {
+                "definition_id": {
                     "type": "string"
                 },
-                "ended": {
+                "deployment_id": {
+                    "type": "string"
+                },
+                "enabled": {
                     "type": "boolean"
                 },
+                "events": {
+                    "description": "event types (started, finished, deleted, incident); all if empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
-                "suspended": {
-                    "type": "boolean"
+                "secret": {
+                    "description": "generated if empty on creation; unchanged if empty on update",
+                    "type": "string"
                 },
-                "tenantId": {
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "model.Variable": {
+        "model.WebhookDelivery": {
             "type": "object",
             "properties": {
-                "type": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "value": {},
-                "valueInfo": {}
-            }
-        },
-        "model.VariableMap": {
-            "type": "object",
-            "additionalProperties": {
-                "$ref": "#/definitions/model.Variable"
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/model.ProcessInstanceEvent"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "type": "integer"
+                },
+                "next_attempt": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "string"
+                }
             }
         }
     },
@@ -24,9 +24,11 @@ import (
 	"net/http"
 	"reflect"
 	"runtime/debug"
+	"slices"
 	"time"
 
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/api/util"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/auth"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/camunda"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/controller"
@@ -35,6 +37,22 @@ import (
 
 //go:generate go tool swag init -o ../../docs --parseDependency -d .. -g api/api.go
 
+// checkExternalTaskMaxPollTimeout ensures that long polling fetch-and-lock requests end before the http client or server timeout
+func checkExternalTaskMaxPollTimeout(config configuration.Config, serverTimeout time.Duration) error {
+	maxPollTimeout, err := time.ParseDuration(config.ExternalTaskMaxPollTimeout)
+	if err != nil || maxPollTimeout <= 0 {
+		return nil //long polling is disabled
+	}
+	if serverTimeout > 0 && maxPollTimeout >= serverTimeout {
+		return fmt.Errorf("external_task_max_poll_timeout (%v) must be less than http_server_timeout (%v)", maxPollTimeout, serverTimeout)
+	}
+	clientTimeout, err := time.ParseDuration(config.HttpClientTimeout)
+	if err == nil && clientTimeout > 0 && maxPollTimeout >= clientTimeout {
+		return fmt.Errorf("external_task_max_poll_timeout (%v) must be less than http_client_timeout (%v)", maxPollTimeout, clientTimeout)
+	}
+	return nil
+}
+
 type EndpointMethod = func(config configuration.Config, router *http.ServeMux, camunda *camunda.Camunda, ctrl *controller.Controller, m Metrics)
 
 var endpoints = []interface{}{} //list of objects with EndpointMethod
@@ -45,14 +63,19 @@ func Start(ctx context.Context, config configuration.Config, camunda *camunda.Ca
 			err = errors.New(fmt.Sprint(r))
 		}
 	}()
-	router := GetRouter(config, camunda, ctrl, m)
-
 	timeout, err := time.ParseDuration(config.HttpServerTimeout)
 	if err != nil {
 		config.GetLogger().Warn("invalid http server timeout --> no timeouts", "error", err)
 		err = nil
 	}
 
+	err = checkExternalTaskMaxPollTimeout(config, timeout)
+	if err != nil {
+		return err
+	}
+
+	router := GetRouter(config, camunda, ctrl, m)
+
 	readtimeout, err := time.ParseDuration(config.HttpServerReadTimeout)
 	if err != nil {
 		config.GetLogger().Warn("invalid http server read timeout --> no timeouts", "error", err)
@@ -60,6 +83,7 @@ func Start(ctx context.Context, config configuration.Config, camunda *camunda.Ca
 	}
 
 	server := &http.Server{Addr: ":" + config.ServerPort, Handler: router, WriteTimeout: timeout, ReadTimeout: readtimeout}
+	server.RegisterOnShutdown(camunda.Events().Close) //ends event streams, which would otherwise block the shutdown
 	go func() {
 		config.GetLogger().Info("listening", "address", server.Addr)
 		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -93,11 +117,25 @@ func GetRouter(config configuration.Config, camunda *camunda.Camunda, ctrl *cont
 			call(config, router, camunda, ctrl, m)
 		}
 	}
-	handler := util.NewCors(router)
-	handler = accesslog.New(handler, accesslog.Options{TrimFormat: config.AccessLogTrimFormat, TrimAttributes: "body"})
-	return handler
+	handler, err := auth.NewVerificationMiddleware(config, router)
+	if err != nil {
+		panic(err)
+	}
+	handler = util.NewCors(handler)
+	logged := accesslog.New(handler, accesslog.Options{TrimFormat: config.AccessLogTrimFormat, TrimAttributes: "body"})
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		//the access log response writer does not support flushing, which is needed by event streams
+		if slices.Contains(streamPaths, request.URL.Path) {
+			handler.ServeHTTP(writer, request)
+			return
+		}
+		logged.ServeHTTP(writer, request)
+	})
 }
 
+// streamPaths are paths of endpoints streaming their response; these requests are not included in the access log
+var streamPaths = []string{"/v2/process-instances/events"}
+
 type Metrics interface {
 	NotifyEventTrigger()
 }
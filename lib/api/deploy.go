@@ -24,6 +24,7 @@ import (
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/controller"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
 	"net/http"
+	"strconv"
 )
 
 func init() {
@@ -35,15 +36,24 @@ type DeployEndpoints struct{}
 // Deploy godoc
 // @Summary      deploy process
 // @Description  deploy process, meant for internal use by the process-deployment service, only admins may access this endpoint
+// @Description  with async=true, the deployment is checked and queued; the response contains the deployment job, which may be polled at /v2/deployment-jobs/{id}
+// @Description  deployments with an id of a queued or running deployment job are rejected with 409
+// @Description  while the deployments of the user are moved to another shard, deployments are rejected with 503
 // @Tags         deployment
+// @Produce      json
 // @Security Bearer
 // @Param        message body model.DeploymentMessage true "deployment"
+// @Param        async query bool false "queue deployment and return the deployment job"
+// @Param        callback_url query string false "url the finished deployment job is posted to; only used with async=true"
 // @Success      200
+// @Success      202 {object} model.DeploymentJob
 // @Failure      400
 // @Failure      401
 // @Failure      403
 // @Failure      404
+// @Failure      409
 // @Failure      500
+// @Failure      503
 // @Router       /process-deployments [PUT]
 func (this *DeployEndpoints) Deploy(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
 	router.HandleFunc("PUT /process-deployments", func(writer http.ResponseWriter, request *http.Request) {
@@ -62,6 +72,18 @@ func (this *DeployEndpoints) Deploy(config configuration.Config, router *http.Se
 			http.Error(writer, "only admins may create deployments", http.StatusForbidden)
 			return
 		}
+		if async, _ := strconv.ParseBool(request.URL.Query().Get("async")); async {
+			job, err, code := e.DeployAsync(depl, request.URL.Query().Get("callback_url"))
+			if err != nil {
+				http.Error(writer, err.Error(), code)
+				return
+			}
+			writer.Header().Set("Location", "/v2/deployment-jobs/"+job.Id)
+			writer.Header().Set("Content-Type", "application/json")
+			writer.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(writer).Encode(job)
+			return
+		}
 		err, code := e.Deploy(depl)
 		if err != nil {
 			http.Error(writer, err.Error(), code)
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/auth"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/controller"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+const IdempotencyKeyHeader = "Idempotency-Key"
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// idempotent wraps a start handler with Controller.StartIdempotent.
+// the key is read from the Idempotency-Key header or, for requests with json body, from the idempotency_key field.
+// replayed responses are marked with the Idempotent-Replayed header.
+func idempotent(config configuration.Config, e *controller.Controller, handler http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		key := request.Header.Get(IdempotencyKeyHeader)
+		body := []byte{}
+		if request.Body != nil && request.Method == http.MethodPost {
+			var err error
+			body, err = io.ReadAll(request.Body)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+			request.Body = io.NopCloser(bytes.NewReader(body))
+			if key == "" {
+				temp := struct {
+					IdempotencyKey string `json:"idempotency_key"`
+				}{}
+				_ = json.Unmarshal(body, &temp) //invalid bodies are rejected by the handler
+				key = temp.IdempotencyKey
+			}
+		}
+		if key == "" || e == nil {
+			handler(writer, request)
+			return
+		}
+
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		hash := sha256.New()
+		hash.Write([]byte(request.Method + " " + request.URL.Path + "?" + request.URL.RawQuery + "\n"))
+		hash.Write(body)
+
+		response, replayed, err, code := e.StartIdempotent(token.GetUserId(), key, hex.EncodeToString(hash.Sum(nil)), func() model.IdempotentResponse {
+			recorder := &responseRecorder{header: http.Header{}, code: http.StatusOK}
+			handler(recorder, request)
+			return model.IdempotentResponse{Code: recorder.code, ContentType: recorder.header.Get("Content-Type"), Body: recorder.body.Bytes()}
+		})
+		if err != nil {
+			config.GetLogger().Warn("unable to handle idempotency key", "user", token.GetUserId(), "key", key, "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		if replayed {
+			writer.Header().Set(IdempotentReplayedHeader, "true")
+		}
+		if response.ContentType != "" {
+			writer.Header().Set("Content-Type", response.ContentType)
+		}
+		writer.WriteHeader(response.Code)
+		_, _ = writer.Write(response.Body)
+	}
+}
+
+// responseRecorder buffers the response of a handler
+type responseRecorder struct {
+	header      http.Header
+	code        int
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+func (this *responseRecorder) Header() http.Header {
+	return this.header
+}
+
+func (this *responseRecorder) Write(b []byte) (int, error) {
+	this.wroteHeader = true
+	return this.body.Write(b)
+}
+
+func (this *responseRecorder) WriteHeader(statusCode int) {
+	if !this.wroteHeader {
+		this.code = statusCode
+		this.wroteHeader = true
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/auth"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/camunda"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/controller"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+func init() {
+	endpoints = append(endpoints, &ShardEndpoints{})
+}
+
+type ShardEndpoints struct{}
+
+// Rebalance godoc
+// @Summary      rebalance user
+// @Description  moves all deployments of a user to another shard and assigns the user to it, only admins may access this endpoint; 409 if a rebalance of the user is already running or process-instances are not drained
+// @Tags         shards
+// @Produce      json
+// @Security Bearer
+// @Param        message body model.RebalanceRequest true "rebalance request"
+// @Success      200 {object}  model.RebalanceResult
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      409
+// @Failure      500
+// @Router       /shards/rebalance [POST]
+func (this *ShardEndpoints) Rebalance(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("POST /shards/rebalance", func(writer http.ResponseWriter, request *http.Request) {
+		msg := model.RebalanceRequest{}
+		err := json.NewDecoder(request.Body).Decode(&msg)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !token.IsAdmin() {
+			http.Error(writer, "only admins may rebalance shards", http.StatusForbidden)
+			return
+		}
+		result, err, code := e.Rebalance(msg)
+		if err != nil {
+			config.GetLogger().Error("error on rebalance", "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
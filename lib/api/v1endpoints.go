@@ -45,7 +45,7 @@ func (this *V1Endpoints) HealthCheck(config configuration.Config, router *http.S
 }
 
 func (this *V1Endpoints) StartProcessDefinition(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("GET /process-definition/{id}/start", func(writer http.ResponseWriter, request *http.Request) {
+	router.HandleFunc("GET /process-definition/{id}/start", idempotent(config, e, func(writer http.ResponseWriter, request *http.Request) {
 		id := request.PathValue("id")
 
 		token, err := auth.GetParsedToken(request)
@@ -61,12 +61,15 @@ func (this *V1Endpoints) StartProcessDefinition(config configuration.Config, rou
 		}
 
 		businessKey := request.URL.Query().Get("business_key")
-		inputs := parseQueryParameter(request.URL.Query())
+		inputs, ok := checkStartParameter(config, writer, c, id, token.GetUserId(), parseQueryParameter(request.URL.Query()), request.URL.Query())
+		if !ok {
+			return
+		}
 
 		err = c.StartProcess(id, businessKey, token.GetUserId(), inputs)
 		if err != nil {
 			config.GetLogger().Error("error on process start", "error", err)
-			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			http.Error(writer, err.Error(), startErrorCode(err))
 			return
 		}
 		//old version returned list of process-variables from history
@@ -76,11 +79,11 @@ func (this *V1Endpoints) StartProcessDefinition(config configuration.Config, rou
 		// 		and ensure that no other services throw errors because of this change
 		writer.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(writer).Encode([]string{})
-	})
+	}))
 }
 
 func (this *V1Endpoints) StartProcessDefinitionAndGetInstanceId(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("GET /process-definition/{id}/start/id", func(writer http.ResponseWriter, request *http.Request) {
+	router.HandleFunc("GET /process-definition/{id}/start/id", idempotent(config, e, func(writer http.ResponseWriter, request *http.Request) {
 		id := request.PathValue("id")
 
 		token, err := auth.GetParsedToken(request)
@@ -96,17 +99,20 @@ func (this *V1Endpoints) StartProcessDefinitionAndGetInstanceId(config configura
 		}
 
 		businessKey := request.URL.Query().Get("business_key")
-		inputs := parseQueryParameter(request.URL.Query())
+		inputs, ok := checkStartParameter(config, writer, c, id, token.GetUserId(), parseQueryParameter(request.URL.Query()), request.URL.Query())
+		if !ok {
+			return
+		}
 
 		result, err := c.StartProcessGetId(id, businessKey, token.GetUserId(), inputs)
 		if err != nil {
 			config.GetLogger().Error("error on process start", "error", err)
-			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			http.Error(writer, err.Error(), startErrorCode(err))
 			return
 		}
 		writer.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(writer).Encode(result)
-	})
+	}))
 }
 
 func (this *V1Endpoints) GetDeployment(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
@@ -163,7 +169,7 @@ func (this *V1Endpoints) DeploymentExists(config configuration.Config, router *h
 }
 
 func (this *V1Endpoints) StartDeployment(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("GET /deployment/{id}/start", func(writer http.ResponseWriter, request *http.Request) {
+	router.HandleFunc("GET /deployment/{id}/start", idempotent(config, e, func(writer http.ResponseWriter, request *http.Request) {
 		id := request.PathValue("id")
 
 		token, err := auth.GetParsedToken(request)
@@ -190,17 +196,20 @@ func (this *V1Endpoints) StartDeployment(config configuration.Config, router *ht
 		}
 
 		businessKey := request.URL.Query().Get("business_key")
-		inputs := parseQueryParameter(request.URL.Query())
+		inputs, ok := checkStartParameter(config, writer, c, definitions[0].Id, token.GetUserId(), parseQueryParameter(request.URL.Query()), request.URL.Query())
+		if !ok {
+			return
+		}
 
 		result, err := c.StartProcessGetId(definitions[0].Id, businessKey, token.GetUserId(), inputs)
 		if err != nil {
 			config.GetLogger().Error("error on process start", "error", err)
-			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			http.Error(writer, err.Error(), startErrorCode(err))
 			return
 		}
 		writer.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(writer).Encode(result)
-	})
+	}))
 }
 
 func (this *V1Endpoints) GetDeploymentParameters(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
@@ -233,7 +242,7 @@ func (this *V1Endpoints) GetDeploymentParameters(config configuration.Config, ro
 		result, err := c.GetProcessParameters(definitions[0].Id, token.GetUserId())
 		if err != nil {
 			config.GetLogger().Error("error on process start", "error", err)
-			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			http.Error(writer, err.Error(), startErrorCode(err))
 			return
 		}
 		writer.Header().Set("Content-Type", "application/json")
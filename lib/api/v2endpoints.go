@@ -22,12 +22,15 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/auth"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/camunda"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/controller"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
 
 	"io"
 )
@@ -46,15 +49,16 @@ type V2Endpoints struct{}
 // @Security Bearer
 // @Param        id path string true "process-definitions id"
 // @Param        business_key query string false "businessKey of started process"
+// @Param        Idempotency-Key header string false "retries with the same key return the response of the first successful start"
 // @Success      200
-// @Failure      400
+// @Failure      400 {object}  model.StartParameterError
 // @Failure      401
 // @Failure      403
 // @Failure      404
 // @Failure      500
 // @Router       /v2/process-definitions/{id}/start [GET]
 func (this *V2Endpoints) StartProcessDefinition(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("GET /v2/process-definitions/{id}/start", func(writer http.ResponseWriter, request *http.Request) {
+	router.HandleFunc("GET /v2/process-definitions/{id}/start", idempotent(config, e, func(writer http.ResponseWriter, request *http.Request) {
 		id := request.PathValue("id")
 
 		token, err := auth.GetParsedToken(request)
@@ -70,12 +74,15 @@ func (this *V2Endpoints) StartProcessDefinition(config configuration.Config, rou
 		}
 
 		businessKey := request.URL.Query().Get("business_key")
-		inputs := parseQueryParameter(request.URL.Query())
+		inputs, ok := checkStartParameter(config, writer, c, id, token.GetUserId(), parseQueryParameter(request.URL.Query()), request.URL.Query())
+		if !ok {
+			return
+		}
 
 		err = c.StartProcess(id, businessKey, token.GetUserId(), inputs)
 		if err != nil {
 			config.GetLogger().Error("error on process start", "error", err)
-			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			http.Error(writer, err.Error(), startErrorCode(err))
 			return
 		}
 		//old version returned list of process-variables from history
@@ -85,7 +92,51 @@ func (this *V2Endpoints) StartProcessDefinition(config configuration.Config, rou
 		// 		and ensure that no other services throw errors because of this change
 		writer.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(writer).Encode([]string{})
-	})
+	}))
+}
+
+// PostStartProcessDefinition godoc
+// @Summary      start process-definition with json body
+// @Description  start process-definition by id with business key and typed variables; variables are validated with the start parameter schema, unless start_parameter_validation is disabled
+// @Tags         start, process-definitions
+// @Accept       json
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "process-definitions id"
+// @Param        message body model.StartRequest true "start request"
+// @Param        Idempotency-Key header string false "retries with the same key return the response of the first successful start"
+// @Success      200 {object}  model.ProcessInstanceWithVariables
+// @Failure      400 {object}  model.StartParameterError
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/process-definitions/{id}/start [POST]
+func (this *V2Endpoints) PostStartProcessDefinition(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("POST /v2/process-definitions/{id}/start", idempotent(config, e, func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+
+		startRequest := model.StartRequest{}
+		err := json.NewDecoder(request.Body).Decode(&startRequest)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := c.CheckProcessDefinitionAccess(id, token.GetUserId()); err != nil {
+			config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
+			http.Error(writer, "Access denied", http.StatusUnauthorized)
+			return
+		}
+
+		startWithRequest(config, writer, c, id, token.GetUserId(), startRequest)
+	}))
 }
 
 // StartProcessDefinitionAndGetInstanceId godoc
@@ -96,15 +147,16 @@ func (this *V2Endpoints) StartProcessDefinition(config configuration.Config, rou
 // @Security Bearer
 // @Param        id path string true "process-definitions id"
 // @Param        business_key query string false "businessKey of started process"
+// @Param        Idempotency-Key header string false "retries with the same key return the response of the first successful start"
 // @Success      200 {object}  model.ProcessInstance
-// @Failure      400
+// @Failure      400 {object}  model.StartParameterError
 // @Failure      401
 // @Failure      403
 // @Failure      404
 // @Failure      500
 // @Router       /v2/process-definitions/{id}/start/id [GET]
 func (this *V2Endpoints) StartProcessDefinitionAndGetInstanceId(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("GET /v2/process-definitions/{id}/start/id", func(writer http.ResponseWriter, request *http.Request) {
+	router.HandleFunc("GET /v2/process-definitions/{id}/start/id", idempotent(config, e, func(writer http.ResponseWriter, request *http.Request) {
 		id := request.PathValue("id")
 
 		token, err := auth.GetParsedToken(request)
@@ -120,17 +172,20 @@ func (this *V2Endpoints) StartProcessDefinitionAndGetInstanceId(config configura
 		}
 
 		businessKey := request.URL.Query().Get("business_key")
-		inputs := parseQueryParameter(request.URL.Query())
+		inputs, ok := checkStartParameter(config, writer, c, id, token.GetUserId(), parseQueryParameter(request.URL.Query()), request.URL.Query())
+		if !ok {
+			return
+		}
 
 		result, err := c.StartProcessGetId(id, businessKey, token.GetUserId(), inputs)
 		if err != nil {
 			config.GetLogger().Error("error on process start", "error", err)
-			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			http.Error(writer, err.Error(), startErrorCode(err))
 			return
 		}
 		writer.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(writer).Encode(result)
-	})
+	}))
 }
 
 // GetDeployment godoc
@@ -218,15 +273,16 @@ func (this *V2Endpoints) DeploymentExists(config configuration.Config, router *h
 // @Security Bearer
 // @Param        id path string true "deployment id"
 // @Param        business_key query string false "businessKey of started process"
+// @Param        Idempotency-Key header string false "retries with the same key return the response of the first successful start"
 // @Success      200 {object}  model.ProcessInstance
-// @Failure      400
+// @Failure      400 {object}  model.StartParameterError
 // @Failure      401
 // @Failure      403
 // @Failure      404
 // @Failure      500
 // @Router       /v2/deployments/{id}/start [GET]
 func (this *V2Endpoints) StartDeployment(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("GET /v2/deployments/{id}/start", func(writer http.ResponseWriter, request *http.Request) {
+	router.HandleFunc("GET /v2/deployments/{id}/start", idempotent(config, e, func(writer http.ResponseWriter, request *http.Request) {
 		id := request.PathValue("id")
 
 		token, err := auth.GetParsedToken(request)
@@ -253,17 +309,141 @@ func (this *V2Endpoints) StartDeployment(config configuration.Config, router *ht
 		}
 
 		businessKey := request.URL.Query().Get("business_key")
-		inputs := parseQueryParameter(request.URL.Query())
+		inputs, ok := checkStartParameter(config, writer, c, definitions[0].Id, token.GetUserId(), parseQueryParameter(request.URL.Query()), request.URL.Query())
+		if !ok {
+			return
+		}
 
 		result, err := c.StartProcessGetId(definitions[0].Id, businessKey, token.GetUserId(), inputs)
 		if err != nil {
 			config.GetLogger().Error("error on process start", "error", err)
+			http.Error(writer, err.Error(), startErrorCode(err))
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	}))
+}
+
+// PostStartDeployment godoc
+// @Summary      start deployment with json body
+// @Description  start deployment by id with business key and typed variables; variables are validated with the start parameter schema, unless start_parameter_validation is disabled
+// @Tags         start, deployment
+// @Accept       json
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "deployment id"
+// @Param        message body model.StartRequest true "start request"
+// @Param        Idempotency-Key header string false "retries with the same key return the response of the first successful start"
+// @Success      200 {object}  model.ProcessInstanceWithVariables
+// @Failure      400 {object}  model.StartParameterError
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/deployments/{id}/start [POST]
+func (this *V2Endpoints) PostStartDeployment(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("POST /v2/deployments/{id}/start", idempotent(config, e, func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+
+		startRequest := model.StartRequest{}
+		err := json.NewDecoder(request.Body).Decode(&startRequest)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := c.CheckDeploymentAccess(id, token.GetUserId()); err != nil {
+			config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
+			http.Error(writer, "Access denied", http.StatusUnauthorized)
+			return
+		}
+		definitions, err := c.GetDefinitionByDeploymentVid(id, token.GetUserId())
+		if err != nil {
+			config.GetLogger().Error("error on getDeploymentByDef", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(definitions) == 0 {
+			config.GetLogger().Error("no definition for deployment found", "error", err)
+			http.Error(writer, "no definition for deployment found", http.StatusInternalServerError)
+			return
+		}
+
+		startWithRequest(config, writer, c, definitions[0].Id, token.GetUserId(), startRequest)
+	}))
+}
+
+// StartDeploymentBatch godoc
+// @Summary      start deployment batch
+// @Description  start many process-instances of a deployment with one request; every item is validated with the start parameter schema; the response lists the started instance or the error of every item in the order of the request
+// @Tags         start, deployment
+// @Accept       json
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "deployment id"
+// @Param        Idempotency-Key header string false "retries with the same key return the response of the first successful start"
+// @Param        message body []model.BatchStartItem true "items to start"
+// @Success      200 {array}  model.BatchStartResult
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/deployments/{id}/start-batch [POST]
+func (this *V2Endpoints) StartDeploymentBatch(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("POST /v2/deployments/{id}/start-batch", idempotent(config, e, func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+
+		items := []model.BatchStartItem{}
+		err := json.NewDecoder(request.Body).Decode(&items)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if config.StartBatchMaxSize > 0 && int64(len(items)) > config.StartBatchMaxSize {
+			http.Error(writer, fmt.Sprintf("batch exceeds start_batch_max_size of %v items", config.StartBatchMaxSize), http.StatusBadRequest)
+			return
+		}
+
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := c.CheckDeploymentAccess(id, token.GetUserId()); err != nil {
+			config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
+			http.Error(writer, "Access denied", http.StatusUnauthorized)
+			return
+		}
+		definitions, err := c.GetDefinitionByDeploymentVid(id, token.GetUserId())
+		if err != nil {
+			config.GetLogger().Error("error on getDeploymentByDef", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(definitions) == 0 {
+			config.GetLogger().Error("no definition for deployment found", "error", err)
+			http.Error(writer, "no definition for deployment found", http.StatusInternalServerError)
+			return
+		}
+
+		result, err := c.StartProcessBatch(definitions[0].Id, token.GetUserId(), items, int(config.StartBatchConcurrency))
+		if err != nil {
+			config.GetLogger().Error("error on process batch start", "error", err)
 			http.Error(writer, err.Error(), http.StatusInternalServerError)
 			return
 		}
 		writer.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(writer).Encode(result)
-	})
+	}))
 }
 
 // GetDeploymentParameters godoc
@@ -310,6 +490,58 @@ func (this *V2Endpoints) GetDeploymentParameters(config configuration.Config, ro
 		result, err := c.GetProcessParameters(definitions[0].Id, token.GetUserId())
 		if err != nil {
 			config.GetLogger().Error("error on process start", "error", err)
+			http.Error(writer, err.Error(), startErrorCode(err))
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// GetDeploymentParameterSchema godoc
+// @Summary      get deployment parameter schema
+// @Description  get json schema of the deployment start parameter, generated from the camunda:formField elements of the start event; start endpoints validate their inputs with this schema, unless start_parameter_validation is disabled
+// @Tags         start, deployment
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "deployment id"
+// @Success      200 {object}  model.JsonSchema
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/deployments/{id}/parameter-schema [GET]
+func (this *V2Endpoints) GetDeploymentParameterSchema(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/deployments/{id}/parameter-schema", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := c.CheckDeploymentAccess(id, token.GetUserId()); err != nil {
+			config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
+			http.Error(writer, "Access denied", http.StatusUnauthorized)
+			return
+		}
+		definitions, err := c.GetDefinitionByDeploymentVid(id, token.GetUserId())
+		if err != nil {
+			config.GetLogger().Error("error on getDeploymentByDef", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(definitions) == 0 {
+			config.GetLogger().Error("no definition for deployment found", "error", err)
+			http.Error(writer, "no definition for deployment found", http.StatusInternalServerError)
+			return
+		}
+
+		result, err := c.GetProcessParameterSchema(definitions[0].Id, token.GetUserId())
+		if err != nil {
+			config.GetLogger().Error("error on getProcessParameterSchema", "error", err)
 			http.Error(writer, err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -394,74 +626,82 @@ func (this *V2Endpoints) GetDeploymentInstances(config configuration.Config, rou
 	})
 }
 
-// ListDeployments godoc
-// @Summary      list deployments
-// @Description  list deployments
+// SetDeploymentSuspended godoc
+// @Summary      suspend or resume deployment
+// @Description  suspends or resumes the process-definitions of a deployment and all their process-instances; suspended deployments can not be started
 // @Tags         deployment
+// @Accept       json
 // @Produce      json
 // @Security Bearer
-// @Success      200 {array}  model.ExtendedDeployment
+// @Param        id path string true "deployment id"
+// @Param        message body model.SuspensionState true "suspension state"
+// @Success      200
 // @Failure      400
 // @Failure      401
 // @Failure      403
 // @Failure      404
 // @Failure      500
-// @Router       /v2/deployments [GET]
-func (this *V2Endpoints) ListDeployments(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("GET /v2/deployments", func(writer http.ResponseWriter, request *http.Request) {
+// @Router       /v2/deployments/{id}/suspended [PUT]
+func (this *V2Endpoints) SetDeploymentSuspended(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("PUT /v2/deployments/{id}/suspended", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		state := model.SuspensionState{}
+		err := json.NewDecoder(request.Body).Decode(&state)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
 		token, err := auth.GetParsedToken(request)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		result, err := c.GetExtendedDeploymentList(token.GetUserId(), request.URL.Query())
-		if errors.Is(err, camunda.UnknownVid) {
-			config.GetLogger().Warn("unable to use vid for process; try repeat", "error", err)
-			time.Sleep(1 * time.Second)
-			result, err = c.GetExtendedDeploymentList(token.GetUserId(), request.URL.Query())
+		if err := c.CheckDeploymentAccess(id, token.GetUserId()); err != nil {
+			config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
+			http.Error(writer, "Access denied", http.StatusUnauthorized)
+			return
 		}
+		err = c.SetDeploymentSuspended(id, token.GetUserId(), state.Suspended)
 		if err != nil {
-			config.GetLogger().Error("error on getDeploymentList", "error", err)
+			config.GetLogger().Error("error on setDeploymentSuspended", "error", err)
 			http.Error(writer, err.Error(), http.StatusInternalServerError)
 			return
 		}
 		writer.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(writer).Encode(result)
+		json.NewEncoder(writer).Encode("ok")
 	})
 }
 
-// GetProcessDefinition godoc
-// @Summary      get process-definition
-// @Description  get process-definition
-// @Tags         process-definition
+// GetDeploymentVersions godoc
+// @Summary      list deployment versions
+// @Description  lists the stored versions of a deployment, newest first; the count of kept inactive versions is configured by deployment_versions_kept
+// @Tags         deployment
 // @Produce      json
 // @Security Bearer
-// @Param        id path string true "process-definition id"
-// @Success      200 {object}  model.ProcessDefinition
+// @Param        id path string true "deployment id"
+// @Success      200 {array}  model.DeploymentVersion
 // @Failure      400
 // @Failure      401
 // @Failure      403
 // @Failure      404
 // @Failure      500
-// @Router       /v2/process-definitions/{id} [GET]
-func (this *V2Endpoints) GetProcessDefinition(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("GET /v2/process-definitions/{id}", func(writer http.ResponseWriter, request *http.Request) {
+// @Router       /v2/deployments/{id}/versions [GET]
+func (this *V2Endpoints) GetDeploymentVersions(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/deployments/{id}/versions", func(writer http.ResponseWriter, request *http.Request) {
 		id := request.PathValue("id")
-
 		token, err := auth.GetParsedToken(request)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-
-		if err := c.CheckProcessDefinitionAccess(id, token.GetUserId()); err != nil {
+		if err := c.CheckDeploymentAccess(id, token.GetUserId()); err != nil {
 			config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
 			http.Error(writer, "Access denied", http.StatusUnauthorized)
 			return
 		}
-		result, err := c.GetProcessDefinition(id, token.GetUserId())
+		result, err := e.GetDeploymentVersions(id)
 		if err != nil {
-			config.GetLogger().Error("error on getProcessDefinition", "error", err)
+			config.GetLogger().Error("error on getDeploymentVersions", "error", err)
 			http.Error(writer, err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -470,60 +710,258 @@ func (this *V2Endpoints) GetProcessDefinition(config configuration.Config, route
 	})
 }
 
-// GetProcessDefinitionDiagram godoc
-// @Summary      get process-definition diagram
-// @Description  get process-definition diagram
-// @Tags         process-definition
+// RollbackDeployment godoc
+// @Summary      rollback deployment
+// @Description  deploys a previous version of the deployment again as new active version with the stored incident handling; new process-instances use the new version, running process-instances are not changed
+// @Tags         deployment
 // @Produce      json
 // @Security Bearer
-// @Param        id path string true "process-definition id"
-// @Success      200 {object}  string
+// @Param        id path string true "deployment id"
+// @Param        version query integer true "version to deploy again"
+// @Success      200
 // @Failure      400
 // @Failure      401
 // @Failure      403
 // @Failure      404
+// @Failure      409
 // @Failure      500
-// @Router       /v2/process-definitions/{id}/diagram [GET]
-func (this *V2Endpoints) GetProcessDefinitionDiagram(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("GET /v2/process-definitions/{id}/diagram", func(writer http.ResponseWriter, request *http.Request) {
+// @Router       /v2/deployments/{id}/rollback [POST]
+func (this *V2Endpoints) RollbackDeployment(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("POST /v2/deployments/{id}/rollback", func(writer http.ResponseWriter, request *http.Request) {
 		id := request.PathValue("id")
+		version, err := strconv.Atoi(request.URL.Query().Get("version"))
+		if err != nil {
+			http.Error(writer, "expect version as integer query parameter", http.StatusBadRequest)
+			return
+		}
 		token, err := auth.GetParsedToken(request)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		if err := c.CheckProcessDefinitionAccess(id, token.GetUserId()); err != nil {
+		if err := c.CheckDeploymentAccess(id, token.GetUserId()); err != nil {
 			config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
 			http.Error(writer, "Access denied", http.StatusUnauthorized)
 			return
 		}
-		result, err := c.GetProcessDefinitionDiagram(id, token.GetUserId())
-		if err != nil {
-			config.GetLogger().Error("error on getProcessDefinitionDiagram", "error", err)
-			http.Error(writer, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		//copy image data
-		_, err = io.Copy(writer, result.Body)
+		err, code := e.RollbackDeployment(token.GetUserId(), id, version)
 		if err != nil {
-			config.GetLogger().Error("error on diagram response copy", "error", err)
-			http.Error(writer, err.Error(), http.StatusNotImplemented)
+			config.GetLogger().Error("error on rollbackDeployment", "error", err)
+			http.Error(writer, err.Error(), code)
 			return
 		}
-		//copy image content type
-		for key, _ := range result.Header {
-			writer.Header().Set(key, result.Header.Get(key))
-		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode("ok")
 	})
 }
 
-// ListProcessInstances godoc
-// @Summary      list process-instances
-// @Description  list process-instances
-// @Tags         process-instance
+// ValidateDeployment godoc
+// @Summary      validate deployment
+// @Description  dry run of a deployment: checks the bpmn like a deployment would, without deploying it to a shard; returns errors and warnings with their position in the xml
+// @Tags         deployment
+// @Accept       json
 // @Produce      json
 // @Security Bearer
-// @Success      200 {array}  model.ProcessInstance
+// @Param        message body model.Deployment true "deployment"
+// @Success      200 {object}  model.DeploymentValidationResult
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /v2/deployments/validate [POST]
+func (this *V2Endpoints) ValidateDeployment(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("POST /v2/deployments/validate", func(writer http.ResponseWriter, request *http.Request) {
+		depl := model.Deployment{}
+		err := json.NewDecoder(request.Body).Decode(&depl)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result := e.ValidateDeployment(token.GetUserId(), depl)
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// GetDeploymentJob godoc
+// @Summary      get deployment job
+// @Description  returns the progress of a deployment; the step is the last finished step; failed jobs contain the error
+// @Tags         deployment
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "deployment job id"
+// @Success      200 {object}  model.DeploymentJob
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/deployment-jobs/{id} [GET]
+func (this *V2Endpoints) GetDeploymentJob(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/deployment-jobs/{id}", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		job, err, code := e.GetDeploymentJob(id)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		if job.UserId != token.GetUserId() && !token.IsAdmin() {
+			http.Error(writer, "Access denied", http.StatusUnauthorized)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(job)
+	})
+}
+
+// ListDeployments godoc
+// @Summary      list deployments
+// @Description  list deployments
+// @Tags         deployment
+// @Produce      json
+// @Security Bearer
+// @Success      200 {array}  model.ExtendedDeployment
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/deployments [GET]
+func (this *V2Endpoints) ListDeployments(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/deployments", func(writer http.ResponseWriter, request *http.Request) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err := c.GetExtendedDeploymentList(token.GetUserId(), request.URL.Query())
+		if errors.Is(err, camunda.UnknownVid) {
+			config.GetLogger().Warn("unable to use vid for process; try repeat", "error", err)
+			time.Sleep(1 * time.Second)
+			result, err = c.GetExtendedDeploymentList(token.GetUserId(), request.URL.Query())
+		}
+		if err != nil {
+			config.GetLogger().Error("error on getDeploymentList", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// GetProcessDefinition godoc
+// @Summary      get process-definition
+// @Description  get process-definition
+// @Tags         process-definition
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "process-definition id"
+// @Success      200 {object}  model.ProcessDefinition
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/process-definitions/{id} [GET]
+func (this *V2Endpoints) GetProcessDefinition(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/process-definitions/{id}", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := c.CheckProcessDefinitionAccess(id, token.GetUserId()); err != nil {
+			config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
+			http.Error(writer, "Access denied", http.StatusUnauthorized)
+			return
+		}
+		result, err := c.GetProcessDefinition(id, token.GetUserId())
+		if err != nil {
+			config.GetLogger().Error("error on getProcessDefinition", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// GetProcessDefinitionDiagram godoc
+// @Summary      get process-definition diagram
+// @Description  get process-definition diagram
+// @Tags         process-definition
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "process-definition id"
+// @Success      200 {object}  string
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/process-definitions/{id}/diagram [GET]
+func (this *V2Endpoints) GetProcessDefinitionDiagram(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/process-definitions/{id}/diagram", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := c.CheckProcessDefinitionAccess(id, token.GetUserId()); err != nil {
+			config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
+			http.Error(writer, "Access denied", http.StatusUnauthorized)
+			return
+		}
+		result, err := c.GetProcessDefinitionDiagram(id, token.GetUserId())
+		if err != nil {
+			config.GetLogger().Error("error on getProcessDefinitionDiagram", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		//copy image data
+		_, err = io.Copy(writer, result.Body)
+		if err != nil {
+			config.GetLogger().Error("error on diagram response copy", "error", err)
+			http.Error(writer, err.Error(), http.StatusNotImplemented)
+			return
+		}
+		//copy image content type
+		for key, _ := range result.Header {
+			writer.Header().Set(key, result.Header.Get(key))
+		}
+	})
+}
+
+// ListProcessInstances godoc
+// @Summary      list process-instances
+// @Description  list process-instances
+// @Tags         process-instance
+// @Produce      json
+// @Security Bearer
+// @Param        limit query int false "max count of returned process-instances"
+// @Param        offset query int false "count of skipped process-instances"
+// @Param        sort query string false "sort field and direction; fields: id, definition_id, definition_key, business_key; directions: asc, desc; e.g. business_key.desc"
+// @Param        business_key query string false "filter by business key"
+// @Param        definition_id query string false "filter by process-definition id"
+// @Param        deployment_id query string false "filter by deployment id"
+// @Param        suspended query bool false "filter by suspension state"
+// @Param        with_total query bool false "if set to true, wraps the result in an objet with the result {total:0, data:[]}"
+// @Success      200 {array}  model.ProcessInstance
 // @Failure      400
 // @Failure      401
 // @Failure      403
@@ -537,7 +975,21 @@ func (this *V2Endpoints) ListProcessInstances(config configuration.Config, route
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		result, err := c.GetProcessInstanceList(token.GetUserId())
+		query, err := camunda.ParseProcessInstanceQuery(request.URL.Query())
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var result interface{}
+		if request.URL.Query().Get("with_total") == "true" {
+			result, err = c.GetFilteredProcessInstanceListWithTotal(token.GetUserId(), query)
+		} else {
+			result, err = c.GetFilteredProcessInstanceList(token.GetUserId(), query)
+		}
+		if errors.Is(err, camunda.UnknownVid) {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
 		if err != nil {
 			config.GetLogger().Error("error on getProcessInstanceList", "error", err)
 			http.Error(writer, err.Error(), http.StatusInternalServerError)
@@ -568,9 +1020,1314 @@ func (this *V2Endpoints) GetProcessInstanceCount(config configuration.Config, ro
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		result, err := c.GetProcessInstanceCount(token.GetUserId())
+		result, err := c.GetProcessInstanceCount(token.GetUserId())
+		if err != nil {
+			config.GetLogger().Error("error on getProcessInstanceCount", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// GetHistoricProcessInstances godoc
+// @Summary      list historic process-instances
+// @Description  list historic process-instances
+// @Tags         process-instance
+// @Produce      json
+// @Security Bearer
+// @Param        with_total query bool false "if set to true, wraps the result in an objet with the result {total:0, data:[]}"
+// @Success      200 {array} model.HistoricProcessInstance
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/history/process-instances [GET]
+func (this *V2Endpoints) GetHistoricProcessInstances(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/history/process-instances", func(writer http.ResponseWriter, request *http.Request) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		query := request.URL.Query()
+		if query.Get("with_total") == "true" {
+			delete(query, "with_total")
+			result, err := c.GetFilteredProcessInstanceHistoryListWithTotal(token.GetUserId(), query)
+			if err != nil {
+				config.GetLogger().Error("error on getFilteredProcessInstanceHistoryList", "error", err)
+				http.Error(writer, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			writer.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(writer).Encode(result)
+		} else {
+			result, err := c.GetFilteredProcessInstanceHistoryList(token.GetUserId(), query)
+			if err != nil {
+				config.GetLogger().Error("error on getFilteredProcessInstanceHistoryList", "error", err)
+				http.Error(writer, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			writer.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(writer).Encode(result)
+		}
+
+	})
+}
+
+// GetProcessInstanceActivities godoc
+// @Summary      get process-instance activities
+// @Description  get the activity instance tree of a running process-instance; the leaves are the currently active activities (activityId references the bpmn element in the process-definition diagram)
+// @Tags         process-instance
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "process-instance id"
+// @Success      200 {object}  model.ActivityInstance
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/process-instances/{id}/activities [GET]
+func (this *V2Endpoints) GetProcessInstanceActivities(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/process-instances/{id}/activities", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err, code := c.CheckProcessInstanceAccess(id, token.GetUserId()); err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		result, err := c.GetProcessInstanceActivities(id, token.GetUserId())
+		if err != nil {
+			config.GetLogger().Error("error on getProcessInstanceActivities", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// GetHistoricProcessInstanceActivities godoc
+// @Summary      get historic process-instance activities
+// @Description  get all started activities of a running or finished process-instance with start and end times, sorted by start time
+// @Tags         process-instance
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "process-instance id"
+// @Success      200 {array}  model.HistoricActivityInstance
+// @Failure      400
+// @Failure      401
+// @Failure      500
+// @Router       /v2/history/process-instances/{id}/activities [GET]
+func (this *V2Endpoints) GetHistoricProcessInstanceActivities(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/history/process-instances/{id}/activities", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if _, err := c.CheckHistoryAccess(id, token.GetUserId()); err != nil {
+			config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
+			http.Error(writer, "Access denied", http.StatusUnauthorized)
+			return
+		}
+		result, err := c.GetHistoricProcessInstanceActivities(id, token.GetUserId())
+		if err != nil {
+			config.GetLogger().Error("error on getHistoricProcessInstanceActivities", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// RestartHistoricProcessInstance godoc
+// @Summary      restart historic process-instance
+// @Description  starts a new process-instance of the same process-definition with the initial variables of a finished process-instance; by default at the start activity of the finished instance
+// @Tags         process-instance
+// @Accept       json
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "historic process-instance id"
+// @Param        message body model.RestartRequest false "restart options"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      409
+// @Failure      500
+// @Router       /v2/history/process-instances/{id}/restart [POST]
+func (this *V2Endpoints) RestartHistoricProcessInstance(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("POST /v2/history/process-instances/{id}/restart", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		msg := model.RestartRequest{}
+		if request.ContentLength != 0 {
+			err := json.NewDecoder(request.Body).Decode(&msg)
+			if err != nil && !errors.Is(err, io.EOF) {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err, code := e.RestartHistoricProcessInstance(token.GetUserId(), id, msg)
+		if err != nil {
+			config.GetLogger().Error("error on restartHistoricProcessInstance", "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode("ok")
+	})
+}
+
+// DeleteHistoricProcessInstance godoc
+// @Summary      delete historic process-instance
+// @Description  delete historic process-instance
+// @Tags         process-instance
+// @Security Bearer
+// @Param        id path string true "process-instance id"
+// @Success      200
+// @Failure      400
+// @Failure      500
+// @Router       /v2/history/process-instances/{id} [DELETE]
+func (this *V2Endpoints) DeleteHistoricProcessInstance(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("DELETE /v2/history/process-instances/{id}", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err, code := e.DeleteHistoricProcessInstance(token.GetUserId(), id)
+		if err != nil {
+			config.GetLogger().Error("error on PublishIncidentDeleteByProcessInstanceEvent", "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode("ok")
+	})
+}
+
+// DeleteProcessInstance godoc
+// @Summary      delete process-instance
+// @Description  delete process-instance
+// @Tags         process-instance
+// @Security Bearer
+// @Param        id path string true "process-instance id"
+// @Success      200
+// @Failure      400
+// @Failure      500
+// @Router       /v2/process-instances/{id} [DELETE]
+func (this *V2Endpoints) DeleteProcessInstance(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("DELETE /v2/process-instances/{id}", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err, code := c.CheckProcessInstanceAccess(id, token.GetUserId()); err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		err = c.RemoveProcessInstance(id, token.GetUserId())
+		if err != nil {
+			config.GetLogger().Error("error on removeProcessInstance", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode("ok")
+	})
+}
+
+// GetProcessInstanceVariables godoc
+// @Summary      get process-instance variables
+// @Description  get the current variables of a process-instance; Json values and Object values serialized as json are returned parsed, Date values as RFC3339
+// @Tags         process-instance
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "process-instance id"
+// @Success      200 {object}  model.VariableMap
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/process-instances/{id}/variables [GET]
+func (this *V2Endpoints) GetProcessInstanceVariables(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/process-instances/{id}/variables", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err, code := c.CheckProcessInstanceAccess(id, token.GetUserId()); err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		result, err := c.GetProcessInstanceVariables(id, token.GetUserId())
+		if err != nil {
+			config.GetLogger().Error("error on getProcessInstanceVariables", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// GetProcessInstanceVariable godoc
+// @Summary      get process-instance variable
+// @Description  get a current variable of a process-instance; Json values and Object values serialized as json are returned parsed, Date values as RFC3339
+// @Tags         process-instance
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "process-instance id"
+// @Param        variable_name path string true "variable name"
+// @Success      200 {object}  model.Variable
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/process-instances/{id}/variables/{variable_name} [GET]
+func (this *V2Endpoints) GetProcessInstanceVariable(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/process-instances/{id}/variables/{variable_name}", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		varName := request.PathValue("variable_name")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err, code := c.CheckProcessInstanceAccess(id, token.GetUserId()); err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		result, err := c.GetProcessInstanceVariable(id, token.GetUserId(), varName)
+		if errors.Is(err, camunda.ErrVariableNotFound) {
+			http.Error(writer, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			config.GetLogger().Error("error on getProcessInstanceVariable", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// UpdateProcessInstanceVariables godoc
+// @Summary      update process-instance variables
+// @Description  sets and deletes multiple variables of a process-instance in one engine call; untyped objects and lists are stored as Json, values of type Json and Object (serializationDataFormat application/json) are passed as json values (strings are stored as json strings), Date values may be passed as RFC3339; variables returned by GET may be sent back unchanged
+// @Tags         process-instance
+// @Accept       json
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "process-instance id"
+// @Param        message body model.VariableModifications true "modifications and deletions"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/process-instances/{id}/variables [PATCH]
+func (this *V2Endpoints) UpdateProcessInstanceVariables(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("PATCH /v2/process-instances/{id}/variables", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		msg := model.VariableModifications{}
+		err := json.NewDecoder(request.Body).Decode(&msg)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err, code := c.CheckProcessInstanceAccess(id, token.GetUserId()); err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		err = c.UpdateProcessInstanceVariables(id, token.GetUserId(), msg)
+		if errors.Is(err, camunda.ErrInvalidVariable) {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			config.GetLogger().Error("error on variable update", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode("ok")
+	})
+}
+
+// SetProcessInstanceVariable godoc
+// @Summary      set process-instance variable
+// @Description  set process-instance variable
+// @Tags         process-instance
+// @Security Bearer
+// @Param        id path string true "process-instance id"
+// @Param        variable_name path string true "variable_name"
+// @Param        message body interface{} true "value"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/process-instances/{id}/variables/{variable_name} [PUT]
+func (this *V2Endpoints) SetProcessInstanceVariable(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("PUT /v2/process-instances/{id}/variables/{variable_name}", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		varName := request.PathValue("variable_name")
+		var varValue interface{}
+		err := json.NewDecoder(request.Body).Decode(&varValue)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err, code := c.CheckProcessInstanceAccess(id, token.GetUserId()); err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		err = c.SetProcessInstanceVariable(id, token.GetUserId(), varName, varValue)
+		if err != nil {
+			config.GetLogger().Error("error on variable update", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode("ok")
+	})
+}
+
+// ModifyProcessInstance godoc
+// @Summary      modify process-instance
+// @Description  starts and cancels activities of a running process-instance (e.g. to repeat a failed task or to skip a task); instruction types: startBeforeActivity, startAfterActivity, startTransition, cancel
+// @Tags         process-instance
+// @Accept       json
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "process-instance id"
+// @Param        message body model.ProcessInstanceModification true "modification"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/process-instances/{id}/modification [POST]
+func (this *V2Endpoints) ModifyProcessInstance(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("POST /v2/process-instances/{id}/modification", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		msg := model.ProcessInstanceModification{}
+		err := json.NewDecoder(request.Body).Decode(&msg)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err, code := c.CheckProcessInstanceAccess(id, token.GetUserId()); err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		err, code := c.ModifyProcessInstance(id, token.GetUserId(), msg)
+		if err != nil {
+			config.GetLogger().Error("error on modifyProcessInstance", "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode("ok")
+	})
+}
+
+// SetProcessInstanceSuspended godoc
+// @Summary      suspend or resume process-instance
+// @Description  suspends or resumes a process-instance; suspended instances keep their state but are not executed
+// @Tags         process-instance
+// @Accept       json
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "process-instance id"
+// @Param        message body model.SuspensionState true "suspension state"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/process-instances/{id}/suspended [PUT]
+func (this *V2Endpoints) SetProcessInstanceSuspended(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("PUT /v2/process-instances/{id}/suspended", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		state := model.SuspensionState{}
+		err := json.NewDecoder(request.Body).Decode(&state)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err, code := c.CheckProcessInstanceAccess(id, token.GetUserId()); err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		err = c.SetProcessInstanceSuspended(id, token.GetUserId(), state.Suspended)
+		if err != nil {
+			config.GetLogger().Error("error on setProcessInstanceSuspended", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode("ok")
+	})
+}
+
+// DeleteProcessMultipleInstances godoc
+// @Summary      delete multiple process-instances
+// @Description  delete multiple process-instances
+// @Tags         process-instance
+// @Security Bearer
+// @Param        message body []string true "ids"
+// @Success      200
+// @Failure      400
+// @Failure      500
+// @Router       /v2/process-instances [DELETE]
+func (this *V2Endpoints) DeleteProcessMultipleInstances(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("DELETE /v2/process-instances", func(writer http.ResponseWriter, request *http.Request) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ids := []string{}
+		err = json.NewDecoder(request.Body).Decode(&ids)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, id := range ids {
+			if err, code := c.CheckProcessInstanceAccess(id, token.GetUserId()); err != nil {
+				http.Error(writer, err.Error(), code)
+				return
+			}
+			err := c.RemoveProcessInstance(id, token.GetUserId())
+			if err != nil {
+				config.GetLogger().Error("error on removeProcessInstance", "error", err)
+				http.Error(writer, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode("ok")
+		return
+	})
+}
+
+// DeleteMultipleHistoricProcessInstances godoc
+// @Summary      delete multiple historic process-instances
+// @Description  delete multiple historic process-instances
+// @Tags         process-instance
+// @Security Bearer
+// @Param        message body []string true "ids"
+// @Success      200
+// @Failure      400
+// @Failure      500
+// @Router       /v2/history/process-instances [DELETE]
+func (this *V2Endpoints) DeleteMultipleHistoricProcessInstances(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("DELETE /v2/history/process-instances", func(writer http.ResponseWriter, request *http.Request) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ids := []string{}
+		err = json.NewDecoder(request.Body).Decode(&ids)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		userId := token.GetUserId()
+		for _, id := range ids {
+			err, code := e.DeleteHistoricProcessInstance(userId, id)
+			if err != nil {
+				config.GetLogger().Error("error on PublishIncidentDeleteByProcessInstanceEvent", "error", err)
+				http.Error(writer, err.Error(), code)
+				return
+			}
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode("ok")
+		return
+	})
+}
+
+// DeleteProcessInstancesByBusinessKey godoc
+// @Summary      delete process-instances by business-key
+// @Description  stops process-instances identified by business-key
+// @Tags         process-instance
+// @Security Bearer
+// @Param        business_key path string true "business-key of instances"
+// @Success      200
+// @Failure      400
+// @Failure      500
+// @Router       /v2/process-instances-by-business-key/{business_key} [DELETE]
+func (this *V2Endpoints) DeleteProcessInstancesByBusinessKey(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("DELETE /v2/process-instances-by-business-key/{business_key}", func(writer http.ResponseWriter, request *http.Request) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		businessKey := request.PathValue("business_key")
+		userId := token.GetUserId()
+		instances, err := c.GetFilteredProcessInstanceHistoryList(userId, url.Values{"processInstanceBusinessKey": {businessKey}})
+		if err != nil {
+			config.GetLogger().Error("error in DeleteProcessInstancesByBusinessKey::GetFilteredProcessInstanceHistoryList", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, instance := range instances {
+			if instance.EndTime == "" && instance.BusinessKey == businessKey {
+				err = errors.Join(err, c.RemoveProcessInstance(instance.Id, userId))
+			}
+		}
+		if err != nil {
+			config.GetLogger().Error("error in DeleteProcessInstancesByBusinessKey", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode("ok")
+		return
+	})
+}
+
+// ref https://docs.camunda.org/rest/camunda-bpm-platform/7.23-SNAPSHOT/#tag/Message/operation/deliverMessage
+type EventTriggerMessage struct {
+	MessageName           string `json:"messageName"`
+	ProcessVariablesLocal map[string]struct {
+		Type  string      `json:"type,omitempty"`
+		Value interface{} `json:"value,omitempty"`
+	} `json:"processVariablesLocal"`
+	ResultEnabled bool   `json:"resultEnabled"`
+	TenantId      string `json:"tenantId"`
+}
+
+// TriggerEvent godoc
+// @Summary      trigger event
+// @Description  trigger event
+// @Tags         event
+// @Produce      json
+// @Security Bearer
+// @Param        message body EventTriggerMessage true "ref https://docs.camunda.org/rest/camunda-bpm-platform/7.23-SNAPSHOT/#tag/Message/operation/deliverMessage"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/event-trigger [POST]
+func (this *V2Endpoints) TriggerEvent(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("POST /v2/event-trigger", func(writer http.ResponseWriter, request *http.Request) {
+		m.NotifyEventTrigger()
+
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		msg := map[string]interface{}{}
+		err = json.Unmarshal(body, &msg)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		userId := token.GetUserId()
+		if token.IsAdmin() {
+			temp, ok := msg["tenantId"]
+			if ok {
+				userId, ok = temp.(string)
+				if !ok {
+					http.Error(writer, "expect string in tenantId", http.StatusBadRequest)
+					return
+				}
+			}
+		}
+		resp, err := c.SendEventTrigger(userId, msg)
+		if err != nil {
+			http.Error(writer, err.Error(), startErrorCode(err))
+			return
+		}
+		fmt.Fprint(writer, resp)
+		return
+	})
+}
+
+// startWithRequest validates the variables of the request with checkStartParameter, starts the process-definition and writes the started process-instance
+func startWithRequest(config configuration.Config, writer http.ResponseWriter, c *camunda.Camunda, processDefinitionId string, userId string, startRequest model.StartRequest) {
+	inputs := map[string]interface{}{}
+	for key, variable := range startRequest.Variables {
+		inputs[key] = variable.Value
+	}
+	if _, ok := checkStartParameter(config, writer, c, processDefinitionId, userId, inputs, nil); !ok {
+		return
+	}
+	result, err := c.StartProcessWithRequest(processDefinitionId, userId, startRequest)
+	if err != nil {
+		config.GetLogger().Error("error on process start", "error", err)
+		http.Error(writer, err.Error(), startErrorCode(err))
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(result)
+}
+
+// startErrorCode returns 503 for starts blocked while the deployments of the user are moved to another shard and 500 otherwise
+func startErrorCode(err error) int {
+	if errors.Is(err, camunda.ErrUserMoving) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// checkStartParameter validates the inputs against the start parameter schema of the process-definition
+// and responds with 400 and the field errors if they are invalid.
+// parseQueryParameter guesses the types of query values; if query is set, values of string fields are replaced by the raw query value
+// and string values of integer and boolean fields are parsed.
+// without config.StartParameterValidation the inputs are returned unchecked.
+func checkStartParameter(config configuration.Config, writer http.ResponseWriter, c *camunda.Camunda, processDefinitionId string, userId string, inputs map[string]interface{}, query url.Values) (result map[string]interface{}, ok bool) {
+	if !config.StartParameterValidation {
+		return inputs, true
+	}
+	schema, err := c.GetProcessParameterSchema(processDefinitionId, userId)
+	if err != nil {
+		config.GetLogger().Error("error on getProcessParameterSchema", "error", err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return inputs, false
+	}
+	if query != nil {
+		for key, property := range schema.Properties {
+			value, exists := inputs[key]
+			if !exists {
+				continue
+			}
+			str, isString := value.(string)
+			switch {
+			case property.Type == "string" && !isString:
+				inputs[key] = query.Get(key)
+			case property.Type == "integer" && isString:
+				if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+					inputs[key] = i
+				}
+			case property.Type == "boolean" && isString:
+				if b, err := strconv.ParseBool(str); err == nil {
+					inputs[key] = b
+				}
+			}
+		}
+	}
+	fieldErrors := camunda.ValidateStartParameter(schema, inputs)
+	if len(fieldErrors) > 0 {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(writer).Encode(model.StartParameterError{Message: "invalid start parameter", Fields: fieldErrors})
+		return inputs, false
+	}
+	return inputs, true
+}
+
+// ListDeploymentSchedules godoc
+// @Summary      list deployment start schedules
+// @Description  lists the start schedules of a deployment with the status of their last run
+// @Tags         start, deployment, schedule
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "deployment id"
+// @Success      200 {array}  model.StartSchedule
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/deployments/{id}/schedules [GET]
+func (this *V2Endpoints) ListDeploymentSchedules(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/deployments/{id}/schedules", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := c.CheckDeploymentAccess(id, token.GetUserId()); err != nil {
+			config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
+			http.Error(writer, "Access denied", http.StatusUnauthorized)
+			return
+		}
+		result, err, code := e.ListSchedules(id)
+		if err != nil {
+			config.GetLogger().Error("error on listSchedules", "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// CreateDeploymentSchedule godoc
+// @Summary      create deployment start schedule
+// @Description  starts a process-instance of the deployment at every match of the cron expression in the time zone of the schedule (default UTC); the business_key may be a go template with the fields .ScheduleId, .DeploymentId and .Time; inputs are validated with the start parameter schema on every run
+// @Tags         start, deployment, schedule
+// @Accept       json
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "deployment id"
+// @Param        message body model.StartSchedule true "schedule; id, deployment_id and run status are ignored"
+// @Success      200 {object}  model.StartSchedule
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/deployments/{id}/schedules [POST]
+func (this *V2Endpoints) CreateDeploymentSchedule(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("POST /v2/deployments/{id}/schedules", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		schedule := model.StartSchedule{}
+		err := json.NewDecoder(request.Body).Decode(&schedule)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := c.CheckDeploymentAccess(id, token.GetUserId()); err != nil {
+			config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
+			http.Error(writer, "Access denied", http.StatusUnauthorized)
+			return
+		}
+		result, err, code := e.CreateSchedule(token.GetUserId(), id, schedule)
+		if err != nil {
+			config.GetLogger().Error("error on createSchedule", "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// GetSchedule godoc
+// @Summary      get start schedule
+// @Description  get start schedule with the status of its last run
+// @Tags         start, schedule
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "schedule id"
+// @Success      200 {object}  model.StartSchedule
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/schedules/{id} [GET]
+func (this *V2Endpoints) GetSchedule(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/schedules/{id}", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := e.GetSchedule(token.GetUserId(), id)
+		if err != nil {
+			config.GetLogger().Warn("error on getSchedule", "user", token.GetUserId(), "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// UpdateSchedule godoc
+// @Summary      update start schedule
+// @Description  replaces cron, time_zone, inputs, business_key and enabled of the schedule; the next run is recalculated
+// @Tags         start, schedule
+// @Accept       json
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "schedule id"
+// @Param        message body model.StartSchedule true "schedule; id, deployment_id and run status are ignored"
+// @Success      200 {object}  model.StartSchedule
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/schedules/{id} [PUT]
+func (this *V2Endpoints) UpdateSchedule(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("PUT /v2/schedules/{id}", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		schedule := model.StartSchedule{}
+		err := json.NewDecoder(request.Body).Decode(&schedule)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := e.UpdateSchedule(token.GetUserId(), id, schedule)
+		if err != nil {
+			config.GetLogger().Warn("error on updateSchedule", "user", token.GetUserId(), "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// DeleteSchedule godoc
+// @Summary      delete start schedule
+// @Description  delete start schedule
+// @Tags         start, schedule
+// @Security Bearer
+// @Param        id path string true "schedule id"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/schedules/{id} [DELETE]
+func (this *V2Endpoints) DeleteSchedule(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("DELETE /v2/schedules/{id}", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err, code := e.DeleteSchedule(token.GetUserId(), id)
+		if err != nil {
+			config.GetLogger().Warn("error on deleteSchedule", "user", token.GetUserId(), "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode("ok")
+	})
+}
+
+const instanceEventBuffer = 100
+const instanceEventKeepAlive = 30 * time.Second
+
+// ProcessInstanceEvents godoc
+// @Summary      process-instance event stream
+// @Description  server-sent events of the process-instances of the user: started and deleted by the wrapper, finished and incidents from the engine history (polled every instance_event_poll_interval); the sse event name is the event type; events are dropped if the client does not keep up; started and deleted events are only sent to streams of the wrapper instance handling the start or delete request, so with multiple replicas a stream may miss them
+// @Tags         process-instance
+// @Produce      text/event-stream
+// @Security Bearer
+// @Param        deployment_id query string false "filter by deployment id"
+// @Param        types query string false "comma separated list of event types: started, finished, deleted, incident; default all"
+// @Success      200 {object}  model.ProcessInstanceEvent
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /v2/process-instances/events [GET]
+func (this *V2Endpoints) ProcessInstanceEvents(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/process-instances/events", func(writer http.ResponseWriter, request *http.Request) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		deploymentId := request.URL.Query().Get("deployment_id")
+		types := map[string]bool{}
+		if typesStr := request.URL.Query().Get("types"); typesStr != "" {
+			for _, t := range strings.Split(typesStr, ",") {
+				types[strings.TrimSpace(t)] = true
+			}
+		}
+
+		rc := http.NewResponseController(writer)
+		err = rc.SetWriteDeadline(time.Time{}) //streams are not limited by http_server_timeout
+		if err != nil && !errors.Is(err, http.ErrNotSupported) {
+			config.GetLogger().Error("unable to remove write deadline of event stream", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		events, cancel := c.Events().Subscribe(token.GetUserId(), instanceEventBuffer)
+		defer cancel()
+
+		writer.Header().Set("Content-Type", "text/event-stream")
+		writer.Header().Set("Cache-Control", "no-cache")
+		writer.Header().Set("X-Accel-Buffering", "no")
+		writer.WriteHeader(http.StatusOK)
+		err = rc.Flush()
+		if err != nil {
+			config.GetLogger().Error("unable to flush event stream", "error", err)
+			return
+		}
+
+		keepAlive := time.NewTicker(instanceEventKeepAlive)
+		defer keepAlive.Stop()
+		for {
+			select {
+			case <-request.Context().Done():
+				return
+			case <-keepAlive.C:
+				_, err = fmt.Fprint(writer, ": keep-alive\n\n")
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if (deploymentId != "" && event.DeploymentId != deploymentId) || (len(types) > 0 && !types[event.Type]) {
+					continue
+				}
+				var msg []byte
+				msg, err = json.Marshal(event)
+				if err == nil {
+					_, err = fmt.Fprintf(writer, "event: %v\ndata: %s\n\n", event.Type, msg)
+				}
+			}
+			if err == nil {
+				err = rc.Flush()
+			}
+			if err != nil {
+				config.GetLogger().Debug("end event stream", "user", token.GetUserId(), "error", err)
+				return
+			}
+		}
+	})
+}
+
+// ListWebhooks godoc
+// @Summary      list webhooks
+// @Description  lists the webhooks of the user; secrets are not included
+// @Tags         webhook
+// @Produce      json
+// @Security Bearer
+// @Success      200 {array}  model.Webhook
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /v2/webhooks [GET]
+func (this *V2Endpoints) ListWebhooks(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/webhooks", func(writer http.ResponseWriter, request *http.Request) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := e.ListWebhooks(token.GetUserId())
+		if err != nil {
+			config.GetLogger().Error("error on listWebhooks", "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// CreateWebhook godoc
+// @Summary      create webhook
+// @Description  the webhook receives signed json payloads (model.WebhookPayload) of process-instance events matching all set filters; the response contains the secret used for the signature, which is generated if not set
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Security Bearer
+// @Param        message body model.Webhook true "webhook; id is ignored"
+// @Success      200 {object}  model.Webhook
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /v2/webhooks [POST]
+func (this *V2Endpoints) CreateWebhook(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("POST /v2/webhooks", func(writer http.ResponseWriter, request *http.Request) {
+		webhook := model.Webhook{}
+		err := json.NewDecoder(request.Body).Decode(&webhook)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if webhook.DeploymentId != "" {
+			if err := c.CheckDeploymentAccess(webhook.DeploymentId, token.GetUserId()); err != nil {
+				config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
+				http.Error(writer, "Access denied", http.StatusUnauthorized)
+				return
+			}
+		}
+		result, err, code := e.CreateWebhook(token.GetUserId(), webhook)
+		if err != nil {
+			config.GetLogger().Error("error on createWebhook", "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// GetWebhook godoc
+// @Summary      get webhook
+// @Description  get webhook; the secret is not included
+// @Tags         webhook
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "webhook id"
+// @Success      200 {object}  model.Webhook
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/webhooks/{id} [GET]
+func (this *V2Endpoints) GetWebhook(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/webhooks/{id}", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := e.GetWebhook(token.GetUserId(), id)
+		if err != nil {
+			config.GetLogger().Warn("error on getWebhook", "user", token.GetUserId(), "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// UpdateWebhook godoc
+// @Summary      update webhook
+// @Description  replaces the webhook; an empty secret keeps the current secret
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "webhook id"
+// @Param        message body model.Webhook true "webhook; id is ignored"
+// @Success      200 {object}  model.Webhook
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/webhooks/{id} [PUT]
+func (this *V2Endpoints) UpdateWebhook(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("PUT /v2/webhooks/{id}", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		webhook := model.Webhook{}
+		err := json.NewDecoder(request.Body).Decode(&webhook)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if webhook.DeploymentId != "" {
+			if err := c.CheckDeploymentAccess(webhook.DeploymentId, token.GetUserId()); err != nil {
+				config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
+				http.Error(writer, "Access denied", http.StatusUnauthorized)
+				return
+			}
+		}
+		result, err, code := e.UpdateWebhook(token.GetUserId(), id, webhook)
+		if err != nil {
+			config.GetLogger().Warn("error on updateWebhook", "user", token.GetUserId(), "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// DeleteWebhook godoc
+// @Summary      delete webhook
+// @Description  deletes the webhook with its pending deliveries and delivery log
+// @Tags         webhook
+// @Security Bearer
+// @Param        id path string true "webhook id"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/webhooks/{id} [DELETE]
+func (this *V2Endpoints) DeleteWebhook(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("DELETE /v2/webhooks/{id}", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err, code := e.DeleteWebhook(token.GetUserId(), id)
+		if err != nil {
+			config.GetLogger().Warn("error on deleteWebhook", "user", token.GetUserId(), "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode("ok")
+	})
+}
+
+// ListWebhookDeliveries godoc
+// @Summary      list webhook deliveries
+// @Description  delivery log of the webhook, newest first; contains pending deliveries (retry queue) and delivered or failed deliveries kept for webhook_delivery_retention
+// @Tags         webhook
+// @Produce      json
+// @Security Bearer
+// @Param        id path string true "webhook id"
+// @Param        limit query int false "max count of returned deliveries; default 100"
+// @Param        offset query int false "count of skipped deliveries"
+// @Success      200 {array}  model.WebhookDelivery
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /v2/webhooks/{id}/deliveries [GET]
+func (this *V2Endpoints) ListWebhookDeliveries(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/webhooks/{id}/deliveries", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		limit := 100
+		offset := 0
+		var err error
+		if limitStr := request.URL.Query().Get("limit"); limitStr != "" {
+			limit, err = strconv.Atoi(limitStr)
+			if err != nil || limit < 0 {
+				http.Error(writer, "expect limit as positive integer", http.StatusBadRequest)
+				return
+			}
+		}
+		if offsetStr := request.URL.Query().Get("offset"); offsetStr != "" {
+			offset, err = strconv.Atoi(offsetStr)
+			if err != nil || offset < 0 {
+				http.Error(writer, "expect offset as positive integer", http.StatusBadRequest)
+				return
+			}
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := e.ListWebhookDeliveries(token.GetUserId(), id, limit, offset)
+		if err != nil {
+			config.GetLogger().Warn("error on listWebhookDeliveries", "user", token.GetUserId(), "error", err)
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// ListIncidents godoc
+// @Summary      list incidents
+// @Description  list open incidents of the user's process-instances, newest first
+// @Tags         incidents
+// @Produce      json
+// @Security Bearer
+// @Param        limit query int false "max count of returned incidents"
+// @Param        offset query int false "count of skipped incidents"
+// @Param        process_instance_id query string false "filter by process-instance id"
+// @Param        definition_id query string false "filter by process-definition id"
+// @Param        deployment_id query string false "filter by deployment id"
+// @Param        incident_type query string false "filter by incident type (e.g. failedJob, failedExternalTask)"
+// @Success      200 {array}  model.Incident
+// @Failure      400
+// @Failure      401
+// @Failure      500
+// @Router       /v2/incidents [GET]
+func (this *V2Endpoints) ListIncidents(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/incidents", func(writer http.ResponseWriter, request *http.Request) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		query, err := camunda.ParseIncidentQuery(request.URL.Query())
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err := c.GetIncidents(token.GetUserId(), query)
+		if errors.Is(err, camunda.UnknownVid) {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
 		if err != nil {
-			config.GetLogger().Error("error on getProcessInstanceCount", "error", err)
+			config.GetLogger().Error("error on getIncidents", "error", err)
 			http.Error(writer, err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -579,73 +2336,68 @@ func (this *V2Endpoints) GetProcessInstanceCount(config configuration.Config, ro
 	})
 }
 
-// GetHistoricProcessInstances godoc
-// @Summary      list historic process-instances
-// @Description  list historic process-instances
-// @Tags         process-instance
+// GetProcessInstanceIncidents godoc
+// @Summary      get process-instance incidents
+// @Description  get the open incidents of a running process-instance, newest first
+// @Tags         process-instance, incidents
 // @Produce      json
 // @Security Bearer
-// @Param        with_total query bool false "if set to true, wraps the result in an objet with the result {total:0, data:[]}"
-// @Success      200 {array} model.HistoricProcessInstance
+// @Param        id path string true "process-instance id"
+// @Success      200 {array}  model.Incident
 // @Failure      400
 // @Failure      401
 // @Failure      403
 // @Failure      404
 // @Failure      500
-// @Router       /v2/history/process-instances [GET]
-func (this *V2Endpoints) GetHistoricProcessInstances(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("GET /v2/history/process-instances", func(writer http.ResponseWriter, request *http.Request) {
+// @Router       /v2/process-instances/{id}/incidents [GET]
+func (this *V2Endpoints) GetProcessInstanceIncidents(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/process-instances/{id}/incidents", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
 		token, err := auth.GetParsedToken(request)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		query := request.URL.Query()
-		if query.Get("with_total") == "true" {
-			delete(query, "with_total")
-			result, err := c.GetFilteredProcessInstanceHistoryListWithTotal(token.GetUserId(), query)
-			if err != nil {
-				config.GetLogger().Error("error on getFilteredProcessInstanceHistoryList", "error", err)
-				http.Error(writer, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			writer.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(writer).Encode(result)
-		} else {
-			result, err := c.GetFilteredProcessInstanceHistoryList(token.GetUserId(), query)
-			if err != nil {
-				config.GetLogger().Error("error on getFilteredProcessInstanceHistoryList", "error", err)
-				http.Error(writer, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			writer.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(writer).Encode(result)
+		if err, code := c.CheckProcessInstanceAccess(id, token.GetUserId()); err != nil {
+			http.Error(writer, err.Error(), code)
+			return
 		}
-
+		result, err := c.GetProcessInstanceIncidents(id, token.GetUserId())
+		if err != nil {
+			config.GetLogger().Error("error on getProcessInstanceIncidents", "error", err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
 	})
 }
 
-// DeleteHistoricProcessInstance godoc
-// @Summary      delete historic process-instance
-// @Description  delete historic process-instance
-// @Tags         process-instance
+// ResolveIncident godoc
+// @Summary      resolve incident
+// @Description  retries the failed job or external task of the incident once; the engine removes the incident with the retry and creates a new one if the retry fails. other incident types are rejected with 400.
+// @Tags         incidents
+// @Produce      json
 // @Security Bearer
-// @Param        id path string true "process-instance id"
+// @Param        id path string true "incident id"
 // @Success      200
 // @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
 // @Failure      500
-// @Router       /v2/history/process-instances/{id} [DELETE]
-func (this *V2Endpoints) DeleteHistoricProcessInstance(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("DELETE /v2/history/process-instances/{id}", func(writer http.ResponseWriter, request *http.Request) {
+// @Router       /v2/incidents/{id}/resolve [POST]
+func (this *V2Endpoints) ResolveIncident(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("POST /v2/incidents/{id}/resolve", func(writer http.ResponseWriter, request *http.Request) {
 		id := request.PathValue("id")
 		token, err := auth.GetParsedToken(request)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		err, code := e.DeleteHistoricProcessInstance(token.GetUserId(), id)
+		err, code := c.ResolveIncident(id, token.GetUserId())
 		if err != nil {
-			config.GetLogger().Error("error on PublishIncidentDeleteByProcessInstanceEvent", "error", err)
+			config.GetLogger().Warn("error on resolveIncident", "error", err)
 			http.Error(writer, err.Error(), code)
 			return
 		}
@@ -654,60 +2406,63 @@ func (this *V2Endpoints) DeleteHistoricProcessInstance(config configuration.Conf
 	})
 }
 
-// DeleteProcessInstance godoc
-// @Summary      delete process-instance
-// @Description  delete process-instance
-// @Tags         process-instance
+// GetDeploymentIncidentHandling godoc
+// @Summary      get deployment incident handling
+// @Description  get the incident handling last set for the deployment by a deployment or by PUT /v2/deployments/{id}/incident-handling; older deployments use the handler registered at the process-incident-api; 404 if none is set
+// @Tags         deployment, incidents
+// @Produce      json
 // @Security Bearer
-// @Param        id path string true "process-instance id"
-// @Success      200
+// @Param        id path string true "deployment id"
+// @Success      200 {object}  model.IncidentHandling
 // @Failure      400
+// @Failure      401
+// @Failure      404
 // @Failure      500
-// @Router       /v2/process-instances/{id} [DELETE]
-func (this *V2Endpoints) DeleteProcessInstance(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("DELETE /v2/process-instances/{id}", func(writer http.ResponseWriter, request *http.Request) {
+// @Router       /v2/deployments/{id}/incident-handling [GET]
+func (this *V2Endpoints) GetDeploymentIncidentHandling(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("GET /v2/deployments/{id}/incident-handling", func(writer http.ResponseWriter, request *http.Request) {
 		id := request.PathValue("id")
 		token, err := auth.GetParsedToken(request)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		if err, code := c.CheckProcessInstanceAccess(id, token.GetUserId()); err != nil {
-			http.Error(writer, err.Error(), code)
+		if err := c.CheckDeploymentAccess(id, token.GetUserId()); err != nil {
+			config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
+			http.Error(writer, "Access denied", http.StatusUnauthorized)
 			return
 		}
-		err = c.RemoveProcessInstance(id, token.GetUserId())
+		result, err, code := e.GetDeploymentIncidentHandling(token.GetUserId(), id)
 		if err != nil {
-			config.GetLogger().Error("error on removeProcessInstance", "error", err)
-			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			config.GetLogger().Warn("error on getDeploymentIncidentHandling", "user", token.GetUserId(), "error", err)
+			http.Error(writer, err.Error(), code)
 			return
 		}
 		writer.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(writer).Encode("ok")
+		json.NewEncoder(writer).Encode(result)
 	})
 }
 
-// SetProcessInstanceVariable godoc
-// @Summary      set process-instance variable
-// @Description  set process-instance variable
-// @Tags         process-instance
+// SetDeploymentIncidentHandling godoc
+// @Summary      set deployment incident handling
+// @Description  registers the incident handling (restart, notify) for the process-definitions of the active deployment version without redeployment; running process-instances are kept and their incidents are handled with the new settings
+// @Tags         deployment, incidents
+// @Accept       json
+// @Produce      json
 // @Security Bearer
-// @Param        id path string true "process-instance id"
-// @Param        variable_name path string true "variable_name"
-// @Param        message body interface{} true "value"
+// @Param        id path string true "deployment id"
+// @Param        message body model.IncidentHandling true "incident handling"
 // @Success      200
 // @Failure      400
 // @Failure      401
-// @Failure      403
 // @Failure      404
 // @Failure      500
-// @Router       /v2/process-instances/{id}/variables/{variable_name} [PUT]
-func (this *V2Endpoints) SetProcessInstanceVariable(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("PUT /v2/process-instances/{id}/variables/{variable_name}", func(writer http.ResponseWriter, request *http.Request) {
+// @Router       /v2/deployments/{id}/incident-handling [PUT]
+func (this *V2Endpoints) SetDeploymentIncidentHandling(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("PUT /v2/deployments/{id}/incident-handling", func(writer http.ResponseWriter, request *http.Request) {
 		id := request.PathValue("id")
-		varName := request.PathValue("variable_name")
-		var varValue interface{}
-		err := json.NewDecoder(request.Body).Decode(&varValue)
+		handling := model.IncidentHandling{}
+		err := json.NewDecoder(request.Body).Decode(&handling)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
@@ -717,14 +2472,15 @@ func (this *V2Endpoints) SetProcessInstanceVariable(config configuration.Config,
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		if err, code := c.CheckProcessInstanceAccess(id, token.GetUserId()); err != nil {
-			http.Error(writer, err.Error(), code)
+		if err := c.CheckDeploymentAccess(id, token.GetUserId()); err != nil {
+			config.GetLogger().Warn("access denied for user", "user", token.GetUserId(), "error", err)
+			http.Error(writer, "Access denied", http.StatusUnauthorized)
 			return
 		}
-		err = c.SetProcessInstanceVariable(id, token.GetUserId(), varName, varValue)
+		err, code := e.SetDeploymentIncidentHandling(token.GetUserId(), id, handling)
 		if err != nil {
-			config.GetLogger().Error("error on variable update", "error", err)
-			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			config.GetLogger().Error("error on setDeploymentIncidentHandling", "error", err)
+			http.Error(writer, err.Error(), code)
 			return
 		}
 		writer.Header().Set("Content-Type", "application/json")
@@ -732,188 +2488,176 @@ func (this *V2Endpoints) SetProcessInstanceVariable(config configuration.Config,
 	})
 }
 
-// DeleteProcessMultipleInstances godoc
-// @Summary      delete multiple process-instances
-// @Description  delete multiple process-instances
-// @Tags         process-instance
+// FetchAndLockExternalTasks godoc
+// @Summary      fetch and lock external tasks
+// @Description  locks external tasks of the given topics for the worker; only tasks of the user's process-instances are returned. asyncResponseTimeout (ms) enables long polling, the request is answered as soon as tasks are available or the timeout is reached; the timeout is limited by the wrapper configuration.
+// @Tags         external-tasks
+// @Accept       json
+// @Produce      json
 // @Security Bearer
-// @Param        message body []string true "ids"
-// @Success      200
+// @Param        message body model.ExternalTaskFetchRequest true "fetch request"
+// @Success      200 {array}  model.LockedExternalTask
 // @Failure      400
+// @Failure      401
 // @Failure      500
-// @Router       /v2/process-instances [DELETE]
-func (this *V2Endpoints) DeleteProcessMultipleInstances(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("DELETE /v2/process-instances", func(writer http.ResponseWriter, request *http.Request) {
-		token, err := auth.GetParsedToken(request)
+// @Router       /v2/external-tasks/fetch-and-lock [POST]
+func (this *V2Endpoints) FetchAndLockExternalTasks(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	maxPollTimeout, err := time.ParseDuration(config.ExternalTaskMaxPollTimeout)
+	if err != nil {
+		config.GetLogger().Warn("invalid external_task_max_poll_timeout --> no long polling", "error", err)
+		maxPollTimeout = 0
+	}
+	router.HandleFunc("POST /v2/external-tasks/fetch-and-lock", func(writer http.ResponseWriter, request *http.Request) {
+		msg := model.ExternalTaskFetchRequest{}
+		err := json.NewDecoder(request.Body).Decode(&msg)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		ids := []string{}
-		err = json.NewDecoder(request.Body).Decode(&ids)
+		if msg.WorkerId == "" || len(msg.Topics) == 0 {
+			http.Error(writer, "expect workerId and at least one topic", http.StatusBadRequest)
+			return
+		}
+		token, err := auth.GetParsedToken(request)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		for _, id := range ids {
-			if err, code := c.CheckProcessInstanceAccess(id, token.GetUserId()); err != nil {
-				http.Error(writer, err.Error(), code)
-				return
-			}
-			err := c.RemoveProcessInstance(id, token.GetUserId())
-			if err != nil {
-				config.GetLogger().Error("error on removeProcessInstance", "error", err)
-				http.Error(writer, err.Error(), http.StatusInternalServerError)
-				return
-			}
+		msg.AsyncResponseTimeout = min(msg.AsyncResponseTimeout, maxPollTimeout.Milliseconds())
+		result, err, code := c.FetchAndLockExternalTasks(request.Context(), token.GetUserId(), msg)
+		if err != nil && request.Context().Err() != nil {
+			//worker closed the connection while polling
+			return
+		}
+		if err != nil {
+			config.GetLogger().Error("error on fetchAndLockExternalTasks", "error", err)
+			http.Error(writer, err.Error(), code)
+			return
 		}
 		writer.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(writer).Encode("ok")
-		return
+		json.NewEncoder(writer).Encode(result)
 	})
 }
 
-// DeleteMultipleHistoricProcessInstances godoc
-// @Summary      delete multiple historic process-instances
-// @Description  delete multiple historic process-instances
-// @Tags         process-instance
+// CompleteExternalTask godoc
+// @Summary      complete external task
+// @Description  completes an external task locked by the worker and sets the given variables
+// @Tags         external-tasks
+// @Accept       json
+// @Produce      json
 // @Security Bearer
-// @Param        message body []string true "ids"
+// @Param        id path string true "external task id"
+// @Param        message body model.ExternalTaskCompleteRequest true "complete request"
 // @Success      200
 // @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
 // @Failure      500
-// @Router       /v2/history/process-instances [DELETE]
-func (this *V2Endpoints) DeleteMultipleHistoricProcessInstances(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("DELETE /v2/history/process-instances", func(writer http.ResponseWriter, request *http.Request) {
-		token, err := auth.GetParsedToken(request)
+// @Router       /v2/external-tasks/{id}/complete [POST]
+func (this *V2Endpoints) CompleteExternalTask(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("POST /v2/external-tasks/{id}/complete", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		msg := model.ExternalTaskCompleteRequest{}
+		err := json.NewDecoder(request.Body).Decode(&msg)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		ids := []string{}
-		err = json.NewDecoder(request.Body).Decode(&ids)
+		token, err := auth.GetParsedToken(request)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		userId := token.GetUserId()
-		for _, id := range ids {
-			err, code := e.DeleteHistoricProcessInstance(userId, id)
-			if err != nil {
-				config.GetLogger().Error("error on PublishIncidentDeleteByProcessInstanceEvent", "error", err)
-				http.Error(writer, err.Error(), code)
-				return
-			}
+		err, code := c.CompleteExternalTask(id, token.GetUserId(), msg)
+		if err != nil {
+			config.GetLogger().Warn("error on completeExternalTask", "user", token.GetUserId(), "error", err)
+			http.Error(writer, err.Error(), code)
+			return
 		}
 		writer.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(writer).Encode("ok")
-		return
 	})
 }
 
-// DeleteProcessInstancesByBusinessKey godoc
-// @Summary      delete process-instances by business-key
-// @Description  stops process-instances identified by business-key
-// @Tags         process-instance
+// HandleExternalTaskFailure godoc
+// @Summary      report external task failure
+// @Description  reports the failure of an external task locked by the worker; the task is retried after retryTimeout (ms) while retries are left, otherwise an incident is created
+// @Tags         external-tasks
+// @Accept       json
+// @Produce      json
 // @Security Bearer
-// @Param        business_key path string true "business-key of instances"
+// @Param        id path string true "external task id"
+// @Param        message body model.ExternalTaskFailureRequest true "failure"
 // @Success      200
 // @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
 // @Failure      500
-// @Router       /v2/process-instances-by-business-key/{business_key} [DELETE]
-func (this *V2Endpoints) DeleteProcessInstancesByBusinessKey(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("DELETE /v2/process-instances-by-business-key/{business_key}", func(writer http.ResponseWriter, request *http.Request) {
-		token, err := auth.GetParsedToken(request)
+// @Router       /v2/external-tasks/{id}/failure [POST]
+func (this *V2Endpoints) HandleExternalTaskFailure(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("POST /v2/external-tasks/{id}/failure", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		msg := model.ExternalTaskFailureRequest{}
+		err := json.NewDecoder(request.Body).Decode(&msg)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		businessKey := request.PathValue("business_key")
-		userId := token.GetUserId()
-		instances, err := c.GetFilteredProcessInstanceHistoryList(userId, url.Values{"processInstanceBusinessKey": {businessKey}})
+		token, err := auth.GetParsedToken(request)
 		if err != nil {
-			config.GetLogger().Error("error in DeleteProcessInstancesByBusinessKey::GetFilteredProcessInstanceHistoryList", "error", err)
-			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		for _, instance := range instances {
-			if instance.EndTime == "" && instance.BusinessKey == businessKey {
-				err = errors.Join(err, c.RemoveProcessInstance(instance.Id, userId))
-			}
-		}
+		err, code := c.HandleExternalTaskFailure(id, token.GetUserId(), msg)
 		if err != nil {
-			config.GetLogger().Error("error in DeleteProcessInstancesByBusinessKey", "error", err)
-			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			config.GetLogger().Warn("error on handleExternalTaskFailure", "user", token.GetUserId(), "error", err)
+			http.Error(writer, err.Error(), code)
 			return
 		}
 		writer.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(writer).Encode("ok")
-		return
 	})
 }
 
-// ref https://docs.camunda.org/rest/camunda-bpm-platform/7.23-SNAPSHOT/#tag/Message/operation/deliverMessage
-type EventTriggerMessage struct {
-	MessageName           string `json:"messageName"`
-	ProcessVariablesLocal map[string]struct {
-		Type  string      `json:"type,omitempty"`
-		Value interface{} `json:"value,omitempty"`
-	} `json:"processVariablesLocal"`
-	ResultEnabled bool   `json:"resultEnabled"`
-	TenantId      string `json:"tenantId"`
-}
-
-// TriggerEvent godoc
-// @Summary      trigger event
-// @Description  trigger event
-// @Tags         event
+// ExtendExternalTaskLock godoc
+// @Summary      extend external task lock
+// @Description  sets the lock of an external task locked by the worker to expire newDuration (ms) from now
+// @Tags         external-tasks
+// @Accept       json
 // @Produce      json
 // @Security Bearer
-// @Param        message body EventTriggerMessage true "ref https://docs.camunda.org/rest/camunda-bpm-platform/7.23-SNAPSHOT/#tag/Message/operation/deliverMessage"
+// @Param        id path string true "external task id"
+// @Param        message body model.ExternalTaskExtendLockRequest true "extend lock request"
 // @Success      200
 // @Failure      400
 // @Failure      401
 // @Failure      403
 // @Failure      404
 // @Failure      500
-// @Router       /v2/event-trigger [POST]
-func (this *V2Endpoints) TriggerEvent(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
-	router.HandleFunc("POST /v2/event-trigger", func(writer http.ResponseWriter, request *http.Request) {
-		m.NotifyEventTrigger()
-
-		token, err := auth.GetParsedToken(request)
-		if err != nil {
-			http.Error(writer, err.Error(), http.StatusBadRequest)
-			return
-		}
-		body, err := io.ReadAll(request.Body)
+// @Router       /v2/external-tasks/{id}/extend-lock [POST]
+func (this *V2Endpoints) ExtendExternalTaskLock(config configuration.Config, router *http.ServeMux, c *camunda.Camunda, e *controller.Controller, m Metrics) {
+	router.HandleFunc("POST /v2/external-tasks/{id}/extend-lock", func(writer http.ResponseWriter, request *http.Request) {
+		id := request.PathValue("id")
+		msg := model.ExternalTaskExtendLockRequest{}
+		err := json.NewDecoder(request.Body).Decode(&msg)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		msg := map[string]interface{}{}
-		err = json.Unmarshal(body, &msg)
+		token, err := auth.GetParsedToken(request)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		userId := token.GetUserId()
-		if token.IsAdmin() {
-			temp, ok := msg["tenantId"]
-			if ok {
-				userId, ok = temp.(string)
-				if !ok {
-					http.Error(writer, "expect string in tenantId", http.StatusBadRequest)
-					return
-				}
-			}
-		}
-		resp, err := c.SendEventTrigger(userId, msg)
+		err, code := c.ExtendExternalTaskLock(id, token.GetUserId(), msg)
 		if err != nil {
-			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			config.GetLogger().Warn("error on extendExternalTaskLock", "user", token.GetUserId(), "error", err)
+			http.Error(writer, err.Error(), code)
 			return
 		}
-		fmt.Fprint(writer, resp)
-		return
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode("ok")
 	})
 }
@@ -0,0 +1,319 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/golang-jwt/jwt"
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// min duration between two jwks requests triggered by unknown key ids
+var JwksMinRefetchInterval = 10 * time.Second
+
+var jwksSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// Verifier checks token signatures against the keys published by a jwks endpoint.
+// keys are cached for config.JwksCacheDuration and refetched early if a token references an unknown key id (key rotation).
+// refetches are limited to one per JwksMinRefetchInterval; while the jwks endpoint is unavailable, the cached keys are used
+type Verifier struct {
+	url           string
+	issuer        string
+	audience      string
+	leeway        time.Duration
+	cacheDuration time.Duration
+
+	fetchMux    sync.Mutex //serializes jwks requests, without blocking requests served from the cache
+	mux         sync.Mutex
+	keys        map[string]crypto.PublicKey
+	lastFetch   time.Time
+	lastAttempt time.Time
+	lastErr     error
+}
+
+// NewVerifier returns nil if config.JwksUrl is not set
+func NewVerifier(config configuration.Config) (result *Verifier, err error) {
+	if config.JwksUrl == "" {
+		return nil, nil
+	}
+	result = &Verifier{
+		url:      config.JwksUrl,
+		issuer:   config.JwtIssuer,
+		audience: config.JwtAudience,
+	}
+	result.cacheDuration, err = time.ParseDuration(config.JwksCacheDuration)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwks_cache_duration: %w", err)
+	}
+	if config.JwtLeeway != "" {
+		result.leeway, err = time.ParseDuration(config.JwtLeeway)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwt_leeway: %w", err)
+		}
+	}
+	return result, nil
+}
+
+func (this *Verifier) Parse(token string) (claims Token, err error) {
+	orig := token
+	if len(token) > 7 && strings.ToLower(token[:7]) == "bearer " {
+		token = token[7:]
+	}
+	verified := verifiedClaims{issuer: this.issuer, audience: this.audience, leeway: this.leeway}
+	parser := jwt.Parser{ValidMethods: jwksSigningMethods}
+	_, err = parser.ParseWithClaims(token, &verified, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return this.getKey(kid)
+	})
+	if err != nil {
+		return claims, err
+	}
+	claims = verified.Token
+	claims.Token = orig
+	return claims, nil
+}
+
+func (this *Verifier) getKey(kid string) (key crypto.PublicKey, err error) {
+	this.mux.Lock()
+	key, ok := this.keys[kid]
+	expired := this.keys == nil || time.Since(this.lastFetch) > this.cacheDuration
+	this.mux.Unlock()
+	if ok && !expired {
+		return key, nil
+	}
+	keys, err := this.refresh()
+	if err != nil {
+		if ok {
+			//jwks endpoint is unavailable --> use the cached key
+			return key, nil
+		}
+		return nil, err
+	}
+	key, ok = keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+// refresh fetches the keys, if the last attempt is older than JwksMinRefetchInterval.
+// otherwise the cached keys and the error of the last attempt are returned
+func (this *Verifier) refresh() (keys map[string]crypto.PublicKey, err error) {
+	this.fetchMux.Lock()
+	defer this.fetchMux.Unlock()
+	this.mux.Lock()
+	keys, err, lastAttempt := this.keys, this.lastErr, this.lastAttempt
+	this.mux.Unlock()
+	if !lastAttempt.IsZero() && time.Since(lastAttempt) < JwksMinRefetchInterval {
+		return keys, err
+	}
+	attempt := time.Now()
+	fetched, err := this.fetch()
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.lastAttempt = attempt
+	this.lastErr = err
+	if err != nil {
+		return this.keys, err
+	}
+	this.keys = fetched
+	this.lastFetch = attempt
+	return fetched, nil
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (this *Verifier) fetch() (keys map[string]crypto.PublicKey, err error) {
+	resp, err := http.Get(this.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		temp, _ := io.ReadAll(resp.Body)
+		return nil, errors.New(resp.Status + " " + string(temp))
+	}
+	set := jsonWebKeySet{}
+	err = json.NewDecoder(resp.Body).Decode(&set)
+	if err != nil {
+		return nil, err
+	}
+	keys = map[string]crypto.PublicKey{}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			//unsupported keys should not prevent the use of the remaining keys
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+func (this jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch this.Kty {
+	case "RSA":
+		n, err := decodeBigInt(this.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(this.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch this.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.New("unsupported curve " + this.Crv)
+		}
+		x, err := decodeBigInt(this.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(this.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, errors.New("unsupported key type " + this.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	temp, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(temp), nil
+}
+
+type verifiedClaims struct {
+	Token
+	Exp int64    `json:"exp,omitempty"`
+	Nbf int64    `json:"nbf,omitempty"`
+	Iss string   `json:"iss,omitempty"`
+	Aud audience `json:"aud,omitempty"`
+
+	issuer   string
+	audience string
+	leeway   time.Duration
+}
+
+func (this *verifiedClaims) Valid() error {
+	now := time.Now()
+	if this.Exp == 0 {
+		return errors.New("missing expiration")
+	}
+	if now.After(time.Unix(this.Exp, 0).Add(this.leeway)) {
+		return errors.New("token is expired")
+	}
+	if this.Nbf != 0 && now.Add(this.leeway).Before(time.Unix(this.Nbf, 0)) {
+		return errors.New("token is not valid yet")
+	}
+	if this.issuer != "" && this.Iss != this.issuer {
+		return errors.New("unexpected issuer")
+	}
+	if this.audience != "" && !slices.Contains(this.Aud, this.audience) {
+		return errors.New("unexpected audience")
+	}
+	return this.Token.Valid()
+}
+
+// aud may be a single string or a list of strings
+type audience []string
+
+func (this *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if json.Unmarshal(data, &single) == nil {
+		*this = audience{single}
+		return nil
+	}
+	var list []string
+	err := json.Unmarshal(data, &list)
+	if err != nil {
+		return err
+	}
+	*this = list
+	return nil
+}
+
+// NewVerificationMiddleware rejects requests with an Authorization header that can not be verified.
+// requests without Authorization header are passed on, handlers requiring a token reject them on their own.
+// if config.JwksUrl is not set, the handler is returned unchanged
+func NewVerificationMiddleware(config configuration.Config, handler http.Handler) (http.Handler, error) {
+	verifier, err := NewVerifier(config)
+	if err != nil {
+		return nil, err
+	}
+	if verifier == nil {
+		return handler, nil
+	}
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		token := GetAuthToken(request)
+		if token != "" {
+			_, err := verifier.Parse(token)
+			if err != nil {
+				config.GetLogger().Debug("reject unverified token", "error", err)
+				http.Error(writer, "invalid token: "+err.Error(), http.StatusUnauthorized)
+				return
+			}
+		}
+		handler.ServeHTTP(writer, request)
+	}), nil
+}
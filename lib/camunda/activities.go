@@ -0,0 +1,52 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package camunda
+
+import (
+	"net/url"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+// GetProcessInstanceActivities returns the activity instance tree of a running process-instance.
+// the root represents the process-instance, the leaves are the currently active activities
+func (this *Camunda) GetProcessInstanceActivities(id string, userId string) (result model.ActivityInstance, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	//"/engine-rest/process-instance/" + id + "/activity-instances"
+	err = Get(shard+"/engine-rest/process-instance/"+url.QueryEscape(id)+"/activity-instances", &result)
+	return
+}
+
+// GetHistoricProcessInstanceActivities returns all started activities of a process-instance, sorted by start time
+func (this *Camunda) GetHistoricProcessInstanceActivities(id string, userId string) (result model.HistoricActivityInstances, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	params := url.Values{
+		"processInstanceId": []string{id},
+		"tenantIdIn":        []string{userId},
+		"sortBy":            []string{"startTime"},
+		"sortOrder":         []string{"asc"},
+	}
+	//"/engine-rest/history/activity-instance"
+	err = Get(shard+"/engine-rest/history/activity-instance?"+params.Encode(), &result)
+	return
+}
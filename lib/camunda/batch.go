@@ -0,0 +1,60 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package camunda
+
+import (
+	"sync"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+// StartProcessBatch validates the inputs of all items with the start parameter schema and starts the valid items with up to concurrency parallel requests.
+// the results have the order of the items; err is only set if the schema is not available, in which case no item is started.
+func (this *Camunda) StartProcessBatch(processDefinitionId string, userId string, items []model.BatchStartItem, concurrency int) (results []model.BatchStartResult, err error) {
+	schema, err := this.GetProcessParameterSchema(processDefinitionId, userId)
+	if err != nil {
+		return results, err
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	results = make([]model.BatchStartResult, len(items))
+	wg := sync.WaitGroup{}
+	semaphore := make(chan struct{}, concurrency)
+	for i, item := range items {
+		results[i].BusinessKey = item.BusinessKey
+		if fieldErrors := ValidateStartParameter(schema, item.Inputs); len(fieldErrors) > 0 {
+			results[i].Error = "invalid start parameter"
+			results[i].Fields = fieldErrors
+			continue
+		}
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			instance, err := this.StartProcessGetId(processDefinitionId, item.BusinessKey, userId, item.Inputs)
+			if err != nil {
+				results[i].Error = err.Error()
+				return
+			}
+			results[i].Instance = &instance
+		}()
+	}
+	wg.Wait()
+	return results, nil
+}
@@ -24,9 +24,11 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/events"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/notification"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/processio"
@@ -44,14 +46,18 @@ type Camunda struct {
 	vid       *vid.Vid
 	config    configuration.Config
 	processIo *processio.ProcessIo
+
+	events           *events.Events
+	definitionVids   sync.Map
+	parameterSchemas sync.Map
 }
 
 func New(config configuration.Config, vid *vid.Vid, shards *shards.Shards, processIo *processio.ProcessIo) *Camunda {
-	return &Camunda{config: config, vid: vid, shards: shards, processIo: processIo}
+	return &Camunda{config: config, vid: vid, shards: shards, processIo: processIo, events: events.New()}
 }
 
 func (this *Camunda) StartProcess(processDefinitionId string, businessKey string, userId string, parameter map[string]interface{}) (err error) {
-	shard, err := this.shards.EnsureShardForUser(userId)
+	shard, err := this.ensureShardForStart(userId)
 	if err != nil {
 		return err
 	}
@@ -80,6 +86,10 @@ func (this *Camunda) StartProcess(processDefinitionId string, businessKey string
 		err = errors.New(resp.Status + " " + string(temp))
 		return
 	}
+	instance := model.ProcessInstance{}
+	if json.Unmarshal(temp, &instance) == nil {
+		this.publishInstanceEvent(shard, model.ProcessInstanceEventStarted, instance, userId)
+	}
 	return nil
 }
 
@@ -93,16 +103,26 @@ func createStartMessage(parameter map[string]interface{}, businessKey string) ma
 	}
 	variables := map[string]interface{}{}
 	for key, val := range parameter {
-		variables[key] = map[string]interface{}{
+		variable := map[string]interface{}{
 			"value": val,
 		}
+		if typed, ok := val.(model.Variable); ok {
+			variable["value"] = typed.Value
+			if typed.Type != "" {
+				variable["type"] = typed.Type
+			}
+			if typed.ValueInfo != nil {
+				variable["valueInfo"] = typed.ValueInfo
+			}
+		}
+		variables[key] = variable
 	}
 	result["variables"] = variables
 	return result
 }
 
 func (this *Camunda) StartProcessGetId(processDefinitionId string, businessKey string, userId string, parameter map[string]interface{}) (result model.ProcessInstance, err error) {
-	shard, err := this.shards.EnsureShardForUser(userId)
+	shard, err := this.ensureShardForStart(userId)
 	if err != nil {
 		return result, err
 	}
@@ -130,6 +150,67 @@ func (this *Camunda) StartProcessGetId(processDefinitionId string, businessKey s
 		return
 	}
 	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err == nil {
+		this.publishInstanceEvent(shard, model.ProcessInstanceEventStarted, result, userId)
+	}
+	return
+}
+
+// StartProcessWithRequest starts the process-definition with typed variables.
+// camunda only returns variables on its start endpoint, which ignores form fields;
+// so with request.WithVariablesInReturn, missing variables are set to the default values of the start parameter schema.
+func (this *Camunda) StartProcessWithRequest(processDefinitionId string, userId string, request model.StartRequest) (result model.ProcessInstanceWithVariables, err error) {
+	shard, err := this.ensureShardForStart(userId)
+	if err != nil {
+		return result, err
+	}
+
+	parameter := map[string]interface{}{}
+	for key, variable := range request.Variables {
+		parameter[key] = variable
+	}
+	endpoint := "/submit-form"
+	if request.WithVariablesInReturn {
+		endpoint = "/start"
+		schema, err := this.GetProcessParameterSchema(processDefinitionId, userId)
+		if err != nil {
+			return result, err
+		}
+		for key, property := range schema.Properties {
+			if _, ok := parameter[key]; !ok && property.Default != nil {
+				parameter[key] = property.Default
+			}
+		}
+	}
+	message := createStartMessage(parameter, request.BusinessKey)
+	if request.WithVariablesInReturn {
+		message["withVariablesInReturn"] = true
+	}
+
+	b := new(bytes.Buffer)
+	err = json.NewEncoder(b).Encode(message)
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequest("POST", shard+"/engine-rest/process-definition/"+url.QueryEscape(processDefinitionId)+endpoint, b)
+	if err != nil {
+		return result, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		temp, _ := io.ReadAll(resp.Body)
+		err = errors.New(resp.Status + " " + string(temp))
+		return
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err == nil {
+		this.publishInstanceEvent(shard, model.ProcessInstanceEventStarted, result.ProcessInstance, userId)
+	}
 	return
 }
 
@@ -226,6 +307,17 @@ func (this *Camunda) RemoveProcessInstance(id string, userId string) (err error)
 	if err != nil {
 		return err
 	}
+	instance := model.ProcessInstance{Id: id}
+	_ = Get(shard+"/engine-rest/process-instance/"+url.QueryEscape(id), &instance) //only used for the deleted event
+	err = removeProcessInstanceForShard(id, shard)
+	if err != nil {
+		return err
+	}
+	this.publishInstanceEvent(shard, model.ProcessInstanceEventDeleted, instance, userId)
+	return nil
+}
+
+func removeProcessInstanceForShard(id string, shard string) (err error) {
 	request, err := http.NewRequest("DELETE", shard+"/engine-rest/process-instance/"+url.QueryEscape(id)+"?skipIoMappings=true", nil)
 	if err != nil {
 		return
@@ -406,21 +498,59 @@ func (this *Camunda) GetDeploymentList(userId string, params url.Values) (result
 	// "/engine-rest/deployment?tenantIdIn="+userId
 	temp := model.CamundaDeployments{}
 	params.Del("tenantIdIn")
+	//with versioning, inactive versions are filtered after the request, so paging is applied to the filtered list
+	//without versioning, inactive versions only remain from previous configurations and paging is left to the engine
+	limit, offset := -1, 0
+	if this.config.DeploymentVersionsKept > 0 {
+		if params.Has("maxResults") {
+			limit, err = strconv.Atoi(params.Get("maxResults"))
+			if err != nil {
+				return result, err
+			}
+			params.Del("maxResults")
+		}
+		if params.Has("firstResult") {
+			offset, err = strconv.Atoi(params.Get("firstResult"))
+			if err != nil {
+				return result, err
+			}
+			params.Del("firstResult")
+		}
+	}
 	path := shard + "/engine-rest/deployment?tenantIdIn=" + url.QueryEscape(userId) + "&" + params.Encode()
 	err = Get(path, &temp)
 	if err != nil {
 		return
 	}
+	ids := make([]string, 0, len(temp))
+	for _, deployment := range temp {
+		ids = append(ids, deployment.Id)
+	}
+	vids, active, err := this.vid.GetVirtualIdsAndStates(ids)
+	if err != nil {
+		return result, err
+	}
 	for i := 0; i < len(temp); i++ {
-		err = this.vid.SetVid(&temp[i])
-		if err != nil {
-			this.config.GetLogger().Warn("unable to find virtual id for process; ignore process", "id", temp[i].Id, "name", temp[i].Name, "error", err)
-			err = nil
-		} else {
-			result = append(result, temp[i])
+		vid, exists := vids[temp[i].Id]
+		if !exists {
+			this.config.GetLogger().Warn("unable to find virtual id for process; ignore process", "id", temp[i].Id, "name", temp[i].Name)
+			continue
+		}
+		if !active[temp[i].Id] {
+			//previous versions are listed by GET /v2/deployments/{id}/versions
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		if limit >= 0 && len(result) >= limit {
+			break
 		}
+		temp[i].SetDeploymentId(vid)
+		result = append(result, temp[i])
 	}
-	return
+	return result, nil
 }
 
 var UnknownVid = errors.New("unknown vid")
@@ -496,6 +626,20 @@ func (this *Camunda) GetDeployment(vid string, userId string) (result model.Camu
 	return
 }
 
+// returns the deployment with the original deploymentId (not vid) and the bpmn and svg files stored by DeployProcess
+func (this *Camunda) GetRawDeploymentWithFiles(deploymentId string, userId string) (result model.CamundaDeployment, xml string, svg string, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, xml, svg, err
+	}
+	err = Get(shard+"/engine-rest/deployment/"+url.QueryEscape(deploymentId), &result)
+	if err != nil {
+		return result, xml, svg, err
+	}
+	xml, svg, err = this.GetDeploymentFilesForShard(shard, deploymentId)
+	return result, xml, svg, err
+}
+
 func (this *Camunda) GetDeploymentCountByShard(deploymentId string, shard string) (result model.Count, err error) {
 	err = Get(shard+"/engine-rest/deployment/count?id="+url.QueryEscape(deploymentId), &result)
 	return
@@ -518,7 +662,16 @@ func buildPayLoad(name string, xml string, svg string, boundary string, owner st
 
 // returns original deploymentId (not vid)
 func (this *Camunda) DeployProcess(name string, xml string, svg string, owner string, source string) (deploymentId string, err error) {
-	responseWrapper, err := this.deployProcess(name, xml, svg, owner, source)
+	shard, err := this.shards.EnsureShardForUser(owner)
+	if err != nil {
+		return deploymentId, err
+	}
+	return this.DeployProcessToShard(shard, name, xml, svg, owner, source)
+}
+
+// returns original deploymentId (not vid)
+func (this *Camunda) DeployProcessToShard(shard string, name string, xml string, svg string, owner string, source string) (deploymentId string, err error) {
+	responseWrapper, err := this.deployProcess(shard, name, xml, svg, owner, source)
 	if err != nil {
 		this.config.GetLogger().Error("unable to decode process engine deployment response", "error", err)
 		return deploymentId, err
@@ -538,7 +691,7 @@ func (this *Camunda) DeployProcess(name string, xml string, svg string, owner st
 				Message: msg,
 			})
 			this.config.GetLogger().Debug("try deploying placeholder process")
-			responseWrapper, err = this.deployProcess(name, CreateBlankProcess(), CreateBlankSvg(), owner, source)
+			responseWrapper, err = this.deployProcess(shard, name, CreateBlankProcess(), CreateBlankSvg(), owner, source)
 			deploymentId, ok = responseWrapper["id"].(string)
 			if !ok {
 				err = errors.New("unable to interpret process engine deployment response")
@@ -557,11 +710,7 @@ func (this *Camunda) DeployProcess(name string, xml string, svg string, owner st
 	return
 }
 
-func (this *Camunda) deployProcess(name string, xml string, svg string, owner string, source string) (result map[string]interface{}, err error) {
-	shard, err := this.shards.EnsureShardForUser(owner)
-	if err != nil {
-		return result, err
-	}
+func (this *Camunda) deployProcess(shard string, name string, xml string, svg string, owner string, source string) (result map[string]interface{}, err error) {
 	result = map[string]interface{}{}
 	boundary := "---------------------------" + time.Now().String()
 	b := strings.NewReader(buildPayLoad(name, xml, svg, boundary, owner, source))
@@ -705,7 +854,7 @@ func (this *Camunda) GetProcessInstanceHistoryListWithTotal(userId string, searc
 }
 
 func (this *Camunda) SendEventTrigger(userId string, msg map[string]interface{}) (response []byte, err error) {
-	shard, err := this.shards.EnsureShardForUser(userId)
+	shard, err := this.ensureShardForStart(userId)
 	if err != nil {
 		return response, err
 	}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package camunda
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/events"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/shards"
+)
+
+// Events returns the process-instance events published by start and delete calls and by the event poller of the controller
+func (this *Camunda) Events() *events.Events {
+	return this.events
+}
+
+// publishInstanceEvent publishes an event of a process-instance started or deleted by the wrapper
+func (this *Camunda) publishInstanceEvent(shard string, eventType string, instance model.ProcessInstance, userId string) {
+	this.events.Publish(model.ProcessInstanceEvent{
+		Type:                eventType,
+		ProcessInstanceId:   instance.Id,
+		ProcessDefinitionId: instance.DefinitionId,
+		DeploymentId:        this.getVidByDefinition(shard, instance.DefinitionId),
+		BusinessKey:         instance.BusinessKey,
+		Time:                time.Now(),
+		UserId:              userId,
+	})
+}
+
+// getVidByDefinition returns the vid of the deployment of the process-definition or "" if unknown.
+// definitions do not change, so results are cached.
+func (this *Camunda) getVidByDefinition(shard string, definitionId string) string {
+	if definitionId == "" || this.vid == nil {
+		return ""
+	}
+	if vid, ok := this.definitionVids.Load(definitionId); ok {
+		return vid.(string)
+	}
+	definition := model.ProcessDefinition{}
+	err := Get(shard+"/engine-rest/process-definition/"+url.QueryEscape(definitionId), &definition)
+	if err != nil {
+		this.config.GetLogger().Warn("unable to get process-definition of event", "definitionId", definitionId, "error", err)
+		return ""
+	}
+	vid, exists, err := this.vid.GetVirtualId(definition.DeploymentId)
+	if err != nil || !exists {
+		return ""
+	}
+	this.definitionVids.Store(definitionId, vid)
+	return vid
+}
+
+// GetShardsOfTenants groups the tenants by their shard; tenants without shard are ignored
+func (this *Camunda) GetShardsOfTenants(tenants []string) (result map[string][]string, err error) {
+	result = map[string][]string{}
+	for _, tenant := range tenants {
+		shard, err := this.shards.GetShardForUser(tenant)
+		if errors.Is(err, shards.ErrorNotFound) {
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		result[shard] = append(result[shard], tenant)
+	}
+	return result, nil
+}
+
+type historicIncident struct {
+	Id                  string `json:"id"`
+	ProcessDefinitionId string `json:"processDefinitionId"`
+	ProcessInstanceId   string `json:"processInstanceId"`
+	CreateTime          string `json:"createTime"`
+	IncidentMessage     string `json:"incidentMessage"`
+	TenantId            string `json:"tenantId"`
+}
+
+// GetFinishedInstanceEventsForShard returns events of process-instances of the tenants (all tenants if empty) finished after the given time.
+// process-instances deleted by a user are reported by the deleting call and are not included.
+func (this *Camunda) GetFinishedInstanceEventsForShard(shard string, tenants []string, after time.Time) (result []model.ProcessInstanceEvent, err error) {
+	query := url.Values{}
+	query.Set("finishedAfter", after.Format(camundaDateFormat))
+	query.Set("sortBy", "endTime")
+	query.Set("sortOrder", "asc")
+	if len(tenants) > 0 {
+		query.Set("tenantIdIn", strings.Join(tenants, ","))
+	}
+	instances := model.HistoricProcessInstances{}
+	err = Get(shard+"/engine-rest/history/process-instance?"+query.Encode(), &instances)
+	if err != nil {
+		return result, err
+	}
+	for _, instance := range instances {
+		if instance.State == "EXTERNALLY_TERMINATED" {
+			continue
+		}
+		endTime, err := time.Parse(camundaDateFormat, instance.EndTime)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, model.ProcessInstanceEvent{
+			Type:                model.ProcessInstanceEventFinished,
+			ProcessInstanceId:   instance.Id,
+			ProcessDefinitionId: instance.ProcessDefinitionId,
+			DeploymentId:        this.getVidByDefinition(shard, instance.ProcessDefinitionId),
+			BusinessKey:         instance.BusinessKey,
+			Time:                endTime,
+			UserId:              instance.TenantId,
+		})
+	}
+	return result, nil
+}
+
+// GetIncidentEventsForShard returns events of incidents of the tenants (all tenants if empty) created after the given time
+func (this *Camunda) GetIncidentEventsForShard(shard string, tenants []string, after time.Time) (result []model.ProcessInstanceEvent, err error) {
+	query := url.Values{}
+	query.Set("createTimeAfter", after.Format(camundaDateFormat))
+	query.Set("sortBy", "createTime")
+	query.Set("sortOrder", "asc")
+	if len(tenants) > 0 {
+		query.Set("tenantIdIn", strings.Join(tenants, ","))
+	}
+	incidents := []historicIncident{}
+	err = Get(shard+"/engine-rest/history/incident?"+query.Encode(), &incidents)
+	if err != nil {
+		return result, err
+	}
+	for _, incident := range incidents {
+		createTime, err := time.Parse(camundaDateFormat, incident.CreateTime)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, model.ProcessInstanceEvent{
+			Type:                model.ProcessInstanceEventIncident,
+			ProcessInstanceId:   incident.ProcessInstanceId,
+			ProcessDefinitionId: incident.ProcessDefinitionId,
+			DeploymentId:        this.getVidByDefinition(shard, incident.ProcessDefinitionId),
+			IncidentId:          incident.Id,
+			IncidentMessage:     incident.IncidentMessage,
+			Time:                createTime,
+			UserId:              incident.TenantId,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package camunda
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+type externalTaskFetchRequest struct {
+	WorkerId             string              `json:"workerId"`
+	MaxTasks             int64               `json:"maxTasks"`
+	UsePriority          bool                `json:"usePriority,omitempty"`
+	AsyncResponseTimeout int64               `json:"asyncResponseTimeout,omitempty"`
+	Topics               []externalTaskTopic `json:"topics"`
+}
+
+type externalTaskTopic struct {
+	model.ExternalTaskTopic
+	TenantIdIn []string `json:"tenantIdIn"`
+}
+
+// FetchAndLockExternalTasks locks external tasks of the user on the user's shard.
+// if request.AsyncResponseTimeout is set, the engine holds the request until tasks are available, the timeout is reached or ctx is done.
+func (this *Camunda) FetchAndLockExternalTasks(ctx context.Context, userId string, request model.ExternalTaskFetchRequest) (result []model.LockedExternalTask, err error, code int) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	fetch := externalTaskFetchRequest{
+		WorkerId:             request.WorkerId,
+		MaxTasks:             request.MaxTasks,
+		UsePriority:          request.UsePriority,
+		AsyncResponseTimeout: request.AsyncResponseTimeout,
+	}
+	for _, topic := range request.Topics {
+		fetch.Topics = append(fetch.Topics, externalTaskTopic{ExternalTaskTopic: topic, TenantIdIn: []string{userId}})
+	}
+	result = []model.LockedExternalTask{}
+	//"/engine-rest/external-task/fetchAndLock"
+	err, code = sendAndDecode(ctx, http.MethodPost, shard+"/engine-rest/external-task/fetchAndLock", fetch, &result)
+	return result, err, code
+}
+
+func (this *Camunda) CompleteExternalTask(id string, userId string, request model.ExternalTaskCompleteRequest) (err error, code int) {
+	return this.sendExternalTaskRequest(id, userId, "complete", request)
+}
+
+func (this *Camunda) HandleExternalTaskFailure(id string, userId string, request model.ExternalTaskFailureRequest) (err error, code int) {
+	return this.sendExternalTaskRequest(id, userId, "failure", request)
+}
+
+func (this *Camunda) ExtendExternalTaskLock(id string, userId string, request model.ExternalTaskExtendLockRequest) (err error, code int) {
+	return this.sendExternalTaskRequest(id, userId, "extendLock", request)
+}
+
+// sendExternalTaskRequest checks that the external task belongs to the user before the request is forwarded to the engine
+func (this *Camunda) sendExternalTaskRequest(id string, userId string, action string, body interface{}) (err error, code int) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	task := model.ExternalTask{}
+	//"/engine-rest/external-task/" + id
+	err, code = getWithCode(shard+"/engine-rest/external-task/"+url.PathEscape(id), &task)
+	if err != nil {
+		return err, code
+	}
+	if task.TenantId != userId {
+		return ErrAccessDenied, http.StatusForbidden
+	}
+	//"/engine-rest/external-task/" + id + "/" + action
+	err, code = send(http.MethodPost, shard+"/engine-rest/external-task/"+url.PathEscape(id)+"/"+action, body)
+	if err != nil {
+		return err, code
+	}
+	return nil, http.StatusOK
+}
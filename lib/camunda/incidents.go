@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package camunda
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+var ErrIncidentNotResolvable = errors.New("only incidents of failed jobs or failed external tasks can be resolved")
+
+// ParseIncidentQuery reads limit, offset, process_instance_id, definition_id, deployment_id and incident_type
+func ParseIncidentQuery(values url.Values) (query model.IncidentQuery, err error) {
+	if limit := values.Get("limit"); limit != "" {
+		query.Limit, err = strconv.Atoi(limit)
+		if err != nil || query.Limit < 0 {
+			return query, fmt.Errorf("%w: limit must be a positive integer", InvalidQuery)
+		}
+	}
+	if offset := values.Get("offset"); offset != "" {
+		query.Offset, err = strconv.Atoi(offset)
+		if err != nil || query.Offset < 0 {
+			return query, fmt.Errorf("%w: offset must be a positive integer", InvalidQuery)
+		}
+	}
+	query.ProcessInstanceId = values.Get("process_instance_id")
+	query.DefinitionId = values.Get("definition_id")
+	query.DeploymentId = values.Get("deployment_id")
+	query.IncidentType = values.Get("incident_type")
+	return query, nil
+}
+
+// GetIncidents returns the open incidents of the user, newest first
+func (this *Camunda) GetIncidents(userId string, query model.IncidentQuery) (result model.Incidents, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	params := url.Values{
+		"tenantIdIn": []string{userId},
+		"sortBy":     []string{"incidentTimestamp"},
+		"sortOrder":  []string{"desc"},
+	}
+	if query.ProcessInstanceId != "" {
+		params.Set("processInstanceId", query.ProcessInstanceId)
+	}
+	if query.DefinitionId != "" {
+		params.Set("processDefinitionId", query.DefinitionId)
+	}
+	if query.DeploymentId != "" {
+		deploymentId, exists, err := this.vid.GetDeploymentId(query.DeploymentId)
+		if err != nil {
+			return result, err
+		}
+		if !exists {
+			return result, UnknownVid
+		}
+		//all versions of a deployment share the process definition keys derived from the vid (see controller.SetProcessId)
+		definitions, err := this.GetRawDefinitionsByDeployment(deploymentId, userId)
+		if err != nil {
+			return result, err
+		}
+		keys := []string{}
+		for _, definition := range definitions {
+			keys = append(keys, definition.Key)
+		}
+		if len(keys) == 0 {
+			return model.Incidents{}, nil
+		}
+		params.Set("processDefinitionKeyIn", strings.Join(keys, ","))
+	}
+	if query.IncidentType != "" {
+		params.Set("incidentType", query.IncidentType)
+	}
+	if query.Limit > 0 {
+		params.Set("maxResults", strconv.Itoa(query.Limit))
+	}
+	if query.Offset > 0 {
+		params.Set("firstResult", strconv.Itoa(query.Offset))
+	}
+	//"/engine-rest/incident"
+	err = Get(shard+"/engine-rest/incident?"+params.Encode(), &result)
+	return
+}
+
+// GetProcessInstanceIncidents returns the open incidents of a process-instance, newest first
+func (this *Camunda) GetProcessInstanceIncidents(id string, userId string) (result model.Incidents, err error) {
+	return this.GetIncidents(userId, model.IncidentQuery{ProcessInstanceId: id})
+}
+
+// ResolveIncident retries the failed job or external task of the incident once; the engine removes the incident with the retry.
+func (this *Camunda) ResolveIncident(id string, userId string) (err error, code int) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	incident := model.Incident{}
+	//"/engine-rest/incident/" + id
+	err, code = getWithCode(shard+"/engine-rest/incident/"+url.PathEscape(id), &incident)
+	if err != nil {
+		return err, code
+	}
+	if incident.TenantId != userId {
+		return ErrAccessDenied, http.StatusForbidden
+	}
+	retries := map[string]interface{}{"retries": 1}
+	switch incident.IncidentType {
+	case model.IncidentTypeFailedJob:
+		//"/engine-rest/job/" + id + "/retries"
+		err, code = send(http.MethodPut, shard+"/engine-rest/job/"+url.PathEscape(incident.Configuration)+"/retries", retries)
+	case model.IncidentTypeFailedExternalTask:
+		//"/engine-rest/external-task/" + id + "/retries"
+		err, code = send(http.MethodPut, shard+"/engine-rest/external-task/"+url.PathEscape(incident.Configuration)+"/retries", retries)
+	default:
+		return ErrIncidentNotResolvable, http.StatusBadRequest
+	}
+	if err != nil {
+		return err, code
+	}
+	return nil, http.StatusOK
+}
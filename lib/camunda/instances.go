@@ -0,0 +1,151 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package camunda
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+var InvalidQuery = errors.New("invalid query")
+
+// wrapper sort fields to camunda sortBy values of /engine-rest/process-instance
+var processInstanceSortFields = map[string]string{
+	"id":             "instanceId",
+	"definition_id":  "definitionId",
+	"definition_key": "definitionKey",
+	"business_key":   "businessKey",
+}
+
+// ParseProcessInstanceQuery reads limit, offset, sort (e.g. business_key.desc), business_key, definition_id, deployment_id and suspended
+func ParseProcessInstanceQuery(values url.Values) (query model.ProcessInstanceQuery, err error) {
+	if limit := values.Get("limit"); limit != "" {
+		query.Limit, err = strconv.Atoi(limit)
+		if err != nil || query.Limit < 0 {
+			return query, fmt.Errorf("%w: limit must be a positive integer", InvalidQuery)
+		}
+	}
+	if offset := values.Get("offset"); offset != "" {
+		query.Offset, err = strconv.Atoi(offset)
+		if err != nil || query.Offset < 0 {
+			return query, fmt.Errorf("%w: offset must be a positive integer", InvalidQuery)
+		}
+	}
+	if sort := values.Get("sort"); sort != "" {
+		field, order, _ := strings.Cut(sort, ".")
+		sortBy, ok := processInstanceSortFields[field]
+		if !ok {
+			return query, fmt.Errorf("%w: unknown sort field %v", InvalidQuery, field)
+		}
+		switch order {
+		case "", "asc":
+			order = "asc"
+		case "desc":
+		default:
+			return query, fmt.Errorf("%w: unknown sort direction %v", InvalidQuery, order)
+		}
+		query.SortBy = sortBy
+		query.SortOrder = order
+	}
+	query.BusinessKey = values.Get("business_key")
+	query.DefinitionId = values.Get("definition_id")
+	query.DeploymentId = values.Get("deployment_id")
+	if suspended := values.Get("suspended"); suspended != "" {
+		b, err := strconv.ParseBool(suspended)
+		if err != nil {
+			return query, fmt.Errorf("%w: suspended must be a boolean", InvalidQuery)
+		}
+		query.Suspended = &b
+	}
+	return query, nil
+}
+
+func (this *Camunda) GetFilteredProcessInstanceList(userId string, query model.ProcessInstanceQuery) (result model.ProcessInstances, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	params, err := this.getProcessInstanceQueryParams(userId, query)
+	if err != nil {
+		return result, err
+	}
+	if query.Limit > 0 {
+		params.Set("maxResults", strconv.Itoa(query.Limit))
+	}
+	if query.Offset > 0 {
+		params.Set("firstResult", strconv.Itoa(query.Offset))
+	}
+	if query.SortBy != "" {
+		params.Set("sortBy", query.SortBy)
+		params.Set("sortOrder", query.SortOrder)
+	}
+	err = Get(shard+"/engine-rest/process-instance?"+params.Encode(), &result)
+	return
+}
+
+func (this *Camunda) GetFilteredProcessInstanceListWithTotal(userId string, query model.ProcessInstanceQuery) (result model.ProcessInstancesWithTotal, err error) {
+	result.Data, err = this.GetFilteredProcessInstanceList(userId, query)
+	if err != nil {
+		return result, err
+	}
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	params, err := this.getProcessInstanceQueryParams(userId, query)
+	if err != nil {
+		return result, err
+	}
+	count := model.Count{}
+	err = Get(shard+"/engine-rest/process-instance/count?"+params.Encode(), &count)
+	result.Total = count.Count
+	return
+}
+
+// returns the filter params shared by /engine-rest/process-instance and /engine-rest/process-instance/count
+func (this *Camunda) getProcessInstanceQueryParams(userId string, query model.ProcessInstanceQuery) (params url.Values, err error) {
+	params = url.Values{"tenantIdIn": []string{userId}}
+	if query.BusinessKey != "" {
+		params.Set("businessKey", query.BusinessKey)
+	}
+	if query.DefinitionId != "" {
+		params.Set("processDefinitionId", query.DefinitionId)
+	}
+	if query.DeploymentId != "" {
+		deploymentId, exists, err := this.vid.GetDeploymentId(query.DeploymentId)
+		if err != nil {
+			return params, err
+		}
+		if !exists {
+			return params, UnknownVid
+		}
+		params.Set("deploymentId", deploymentId)
+	}
+	if query.Suspended != nil {
+		if *query.Suspended {
+			params.Set("suspended", "true")
+		} else {
+			params.Set("active", "true")
+		}
+	}
+	return params, nil
+}
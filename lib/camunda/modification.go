@@ -0,0 +1,127 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package camunda
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+var ErrInvalidModification = errors.New("invalid modification")
+
+// ModifyProcessInstance starts and cancels activities of a running process-instance.
+// the returned code forwards client errors of the engine (e.g. unknown activity ids)
+func (this *Camunda) ModifyProcessInstance(id string, userId string, modification model.ProcessInstanceModification) (err error, code int) {
+	err = ValidateModification(modification)
+	if err != nil {
+		return err, http.StatusBadRequest
+	}
+	for i, instruction := range modification.Instructions {
+		if len(instruction.Variables) == 0 {
+			continue
+		}
+		variables := model.VariableMap{}
+		for name, variable := range instruction.Variables {
+			variables[name], err = SerializeVariable(variable)
+			if err != nil {
+				return fmt.Errorf("%w: %v: %v", ErrInvalidVariable, name, err.Error()), http.StatusBadRequest
+			}
+		}
+		modification.Instructions[i].Variables = variables
+	}
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	//"/engine-rest/process-instance/" + id + "/modification"
+	err, code = send(http.MethodPost, shard+"/engine-rest/process-instance/"+url.QueryEscape(id)+"/modification", modification)
+	if err != nil && (code < 400 || code >= 500) {
+		code = http.StatusInternalServerError
+	}
+	return err, code
+}
+
+func ValidateModification(modification model.ProcessInstanceModification) error {
+	if len(modification.Instructions) == 0 {
+		return fmt.Errorf("%w: missing instructions", ErrInvalidModification)
+	}
+	for i, instruction := range modification.Instructions {
+		switch instruction.Type {
+		case model.ModificationStartBeforeActivity, model.ModificationStartAfterActivity:
+			if instruction.ActivityId == "" {
+				return fmt.Errorf("%w: instruction %v: missing activityId", ErrInvalidModification, i)
+			}
+		case model.ModificationStartTransition:
+			if instruction.TransitionId == "" {
+				return fmt.Errorf("%w: instruction %v: missing transitionId", ErrInvalidModification, i)
+			}
+		case model.ModificationCancel:
+			if instruction.ActivityId == "" && instruction.ActivityInstanceId == "" && instruction.TransitionInstanceId == "" {
+				return fmt.Errorf("%w: instruction %v: expect activityId, activityInstanceId or transitionInstanceId", ErrInvalidModification, i)
+			}
+		default:
+			return fmt.Errorf("%w: instruction %v: unknown type %v", ErrInvalidModification, i, instruction.Type)
+		}
+	}
+	return nil
+}
+
+func (this *Camunda) GetHistoricProcessInstance(id string, userId string) (result model.HistoricProcessInstance, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	//"/engine-rest/history/process-instance/" + id
+	err = Get(shard+"/engine-rest/history/process-instance/"+url.QueryEscape(id), &result)
+	return
+}
+
+// RestartProcessInstance starts a new process-instance of the same process-definition with the initial variables of the given historic instance
+func (this *Camunda) RestartProcessInstance(instance model.HistoricProcessInstance, userId string, request model.RestartRequest) (err error, code int) {
+	shard, err := this.ensureShardForStart(userId)
+	if errors.Is(err, ErrUserMoving) {
+		return err, http.StatusServiceUnavailable
+	}
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	startActivityId := request.StartActivityId
+	if startActivityId == "" {
+		startActivityId = instance.StartActivityId
+	}
+	msg := map[string]interface{}{
+		"processInstanceIds": []string{instance.Id},
+		"instructions": []model.ModificationInstruction{{
+			Type:       model.ModificationStartBeforeActivity,
+			ActivityId: startActivityId,
+		}},
+		"initialVariables":    true,
+		"skipCustomListeners": request.SkipCustomListeners,
+		"skipIoMappings":      request.SkipIoMappings,
+		"withoutBusinessKey":  request.WithoutBusinessKey,
+	}
+	//"/engine-rest/process-definition/" + id + "/restart"
+	err, code = send(http.MethodPost, shard+"/engine-rest/process-definition/"+url.QueryEscape(instance.ProcessDefinitionId)+"/restart", msg)
+	if err != nil && (code < 400 || code >= 500) {
+		code = http.StatusInternalServerError
+	}
+	return err, code
+}
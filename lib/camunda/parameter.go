@@ -107,11 +107,18 @@ func (this *Camunda) filterParameter(shard string, id string, variables map[stri
 }
 
 type ProcessStartParameter struct {
-	Id         string            `json:"id"`
-	Label      string            `json:"label"`
-	Type       string            `json:"type"`
-	Default    string            `json:"default"`
-	Properties map[string]string `json:"properties"`
+	Id          string                       `json:"id"`
+	Label       string                       `json:"label"`
+	Type        string                       `json:"type"`
+	Default     string                       `json:"default"`
+	Properties  map[string]string            `json:"properties"`
+	Values      []ProcessStartParameterValue `json:"values,omitempty"`      //values of enum fields
+	Constraints map[string]string            `json:"constraints,omitempty"` //config by constraint name (e.g. required, min, maxlength)
+}
+
+type ProcessStartParameterValue struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
 }
 
 func (this *Camunda) estimateStartParameter(xml string) (result []ProcessStartParameter, err error) {
@@ -141,12 +148,28 @@ func (this *Camunda) estimateStartParameter(xml string) (result []ProcessStartPa
 					properties[propertyName] = propertyValue
 				}
 			}
+			values := []ProcessStartParameterValue{}
+			for _, value := range element.SelectElements("camunda:value") {
+				values = append(values, ProcessStartParameterValue{
+					Id:   value.SelectAttrValue("id", ""),
+					Name: value.SelectAttrValue("name", ""),
+				})
+			}
+			constraints := map[string]string{}
+			for _, constraint := range element.FindElements("./camunda:validation/camunda:constraint") {
+				name := constraint.SelectAttrValue("name", "")
+				if name != "" {
+					constraints[name] = constraint.SelectAttrValue("config", "")
+				}
+			}
 			result = append(result, ProcessStartParameter{
-				Id:         id,
-				Label:      label,
-				Type:       paramtype,
-				Default:    defaultValue,
-				Properties: properties,
+				Id:          id,
+				Label:       label,
+				Type:        paramtype,
+				Default:     defaultValue,
+				Properties:  properties,
+				Values:      values,
+				Constraints: constraints,
 			})
 		}
 	}
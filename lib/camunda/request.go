@@ -17,7 +17,10 @@
 package camunda
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -35,3 +38,83 @@ func Get(url string, result interface{}) (err error) {
 	}
 	return json.NewDecoder(resp.Body).Decode(result)
 }
+
+// getWithCode works like Get and returns the status code of the engine response, to allow the forwarding of client errors (4xx)
+func getWithCode(endpoint string, result interface{}) (err error, code int) {
+	resp, err := http.Get(endpoint)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		temp, _ := io.ReadAll(resp.Body)
+		return errors.New(resp.Status + " " + string(temp)), resp.StatusCode
+	}
+	err = json.NewDecoder(resp.Body).Decode(result)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, resp.StatusCode
+}
+
+func put(endpoint string, body interface{}) error {
+	err, _ := send(http.MethodPut, endpoint, body)
+	return err
+}
+
+func post(endpoint string, body interface{}) error {
+	err, _ := send(http.MethodPost, endpoint, body)
+	return err
+}
+
+// send returns the status code of the engine response, to allow the forwarding of client errors (4xx)
+func send(method string, endpoint string, body interface{}) (err error, code int) {
+	b := new(bytes.Buffer)
+	err = json.NewEncoder(b).Encode(body)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	req, err := http.NewRequest(method, endpoint, b)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	defer resp.Body.Close()
+	temp, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 300 {
+		return errors.New(resp.Status + " " + string(temp)), resp.StatusCode
+	}
+	return nil, resp.StatusCode
+}
+
+// sendAndDecode works like send and decodes the engine response into result; the engine request is canceled with ctx
+func sendAndDecode(ctx context.Context, method string, endpoint string, body interface{}, result interface{}) (err error, code int) {
+	b := new(bytes.Buffer)
+	err = json.NewEncoder(b).Encode(body)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, b)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		temp, _ := io.ReadAll(resp.Body)
+		return errors.New(resp.Status + " " + string(temp)), resp.StatusCode
+	}
+	err = json.NewDecoder(resp.Body).Decode(result)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, resp.StatusCode
+}
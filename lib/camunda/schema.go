@@ -0,0 +1,226 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package camunda
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+const JsonSchemaVersion = "https://json-schema.org/draft/2020-12/schema"
+
+// GetProcessParameterSchema returns the json schema of the start parameters of the process-definition.
+// definitions do not change, so results are cached; the returned schema is shared and must not be modified.
+func (this *Camunda) GetProcessParameterSchema(processDefinitionId string, userId string) (result model.JsonSchema, err error) {
+	if schema, ok := this.parameterSchemas.Load(processDefinitionId); ok {
+		return schema.(model.JsonSchema), nil
+	}
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	xml, err := this.getProcessDefinitionXml(shard, processDefinitionId)
+	if err != nil {
+		return result, err
+	}
+	result, err = this.GetStartParameterSchema(xml.Bpmn)
+	if err != nil {
+		return result, err
+	}
+	this.parameterSchemas.Store(processDefinitionId, result)
+	return result, nil
+}
+
+// GetStartParameterSchema returns the json schema of the camunda:formField elements of the start events in the bpmn xml
+func (this *Camunda) GetStartParameterSchema(xml string) (result model.JsonSchema, err error) {
+	parameter, err := this.estimateStartParameter(xml)
+	if err != nil {
+		return result, err
+	}
+	return StartParameterSchema(parameter), nil
+}
+
+// StartParameterSchema converts form fields to a json schema; fields with the property ignore_on_start are skipped.
+// required fields with a default value are not listed as required, because camunda uses the default if the field is missing.
+func StartParameterSchema(parameter []ProcessStartParameter) (result model.JsonSchema) {
+	result = model.JsonSchema{
+		Schema:     JsonSchemaVersion,
+		Type:       "object",
+		Properties: map[string]model.JsonSchema{},
+	}
+	for _, param := range parameter {
+		if param.Properties["ignore_on_start"] == "true" {
+			continue
+		}
+		property := model.JsonSchema{Title: param.Label}
+		if property.Title == "" {
+			property.Title = param.Id
+		}
+		switch param.Type {
+		case "long":
+			property.Type = "integer"
+		case "boolean":
+			property.Type = "boolean"
+		case "string", "date":
+			property.Type = "string"
+		case "enum":
+			property.Type = "string"
+			property.EnumLabels = map[string]string{}
+			for _, value := range param.Values {
+				property.Enum = append(property.Enum, value.Id)
+				property.EnumLabels[value.Id] = value.Name
+			}
+		}
+		property.Default = parseDefaultValue(property.Type, param.Default)
+		for name, config := range param.Constraints {
+			switch name {
+			case "required":
+				if param.Default == "" {
+					result.Required = append(result.Required, param.Id)
+				}
+			case "readonly":
+				property.ReadOnly = true
+			case "min":
+				if f, err := strconv.ParseFloat(config, 64); err == nil {
+					property.Minimum = &f
+				}
+			case "max":
+				if f, err := strconv.ParseFloat(config, 64); err == nil {
+					property.Maximum = &f
+				}
+			case "minlength":
+				if i, err := strconv.ParseInt(config, 10, 64); err == nil {
+					property.MinLength = &i
+				}
+			case "maxlength":
+				if i, err := strconv.ParseInt(config, 10, 64); err == nil {
+					property.MaxLength = &i
+				}
+			}
+		}
+		result.Properties[param.Id] = property
+	}
+	slices.Sort(result.Required)
+	return result
+}
+
+// parseDefaultValue returns nil for missing or expression (e.g. ${now()}) defaults and for defaults not matching the type
+func parseDefaultValue(schemaType string, value string) interface{} {
+	if value == "" || strings.Contains(value, "${") || strings.Contains(value, "#{") {
+		return nil
+	}
+	switch schemaType {
+	case "integer":
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil
+		}
+		return i
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil
+		}
+		return b
+	default:
+		return value
+	}
+}
+
+// ValidateStartParameter checks the parameter against a schema created by StartParameterSchema.
+// parameters without property in the schema are not checked.
+func ValidateStartParameter(schema model.JsonSchema, parameter map[string]interface{}) (errs []model.FieldError) {
+	for _, field := range schema.Required {
+		if parameter[field] == nil {
+			errs = append(errs, model.FieldError{Field: field, Message: "required"})
+		}
+	}
+	for field, property := range schema.Properties {
+		value := parameter[field]
+		if value == nil {
+			continue
+		}
+		if err := validateStartParameterValue(property, value); err != nil {
+			errs = append(errs, model.FieldError{Field: field, Message: err.Error()})
+		}
+	}
+	slices.SortFunc(errs, func(a, b model.FieldError) int {
+		return strings.Compare(a.Field, b.Field)
+	})
+	return errs
+}
+
+func validateStartParameterValue(property model.JsonSchema, value interface{}) error {
+	if property.ReadOnly {
+		return fmt.Errorf("read only")
+	}
+	number, isNumber := toFloat(value)
+	str, isString := value.(string)
+	switch property.Type {
+	case "integer":
+		if !isNumber || number != float64(int64(number)) {
+			return fmt.Errorf("expected integer")
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected boolean")
+		}
+	case "string":
+		if !isString {
+			return fmt.Errorf("expected string")
+		}
+	}
+	if len(property.Enum) > 0 && !slices.Contains(property.Enum, value) {
+		return fmt.Errorf("expected one of %v", property.Enum)
+	}
+	if isNumber && property.Minimum != nil && number < *property.Minimum {
+		return fmt.Errorf("must be greater than or equal to %v", *property.Minimum)
+	}
+	if isNumber && property.Maximum != nil && number > *property.Maximum {
+		return fmt.Errorf("must be less than or equal to %v", *property.Maximum)
+	}
+	if isString && property.MinLength != nil && int64(utf8.RuneCountInString(str)) < *property.MinLength {
+		return fmt.Errorf("must have at least %v characters", *property.MinLength)
+	}
+	if isString && property.MaxLength != nil && int64(utf8.RuneCountInString(str)) > *property.MaxLength {
+		return fmt.Errorf("must have at most %v characters", *property.MaxLength)
+	}
+	return nil
+}
+
+func toFloat(value interface{}) (result float64, ok bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
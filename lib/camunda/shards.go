@@ -0,0 +1,200 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package camunda
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+var ErrUserMoving = errors.New("deployments of the user are moved to another shard, retry later")
+
+// the following methods work on explicitly given shards instead of the shard assigned to a user
+// and are meant to be used while moving users between shards
+
+func (this *Camunda) GetShardForUser(userId string) (shard string, err error) {
+	return this.shards.GetShardForUser(userId)
+}
+
+func (this *Camunda) SetShardForUser(userId string, shard string) (err error) {
+	return this.shards.SetShardForUser(userId, shard)
+}
+
+// SetUserMoving blocks process starts and deployments of the user until UnsetUserMoving is called or until is reached
+func (this *Camunda) SetUserMoving(userId string, until time.Time) (err error) {
+	return this.shards.SetUserMoving(userId, until)
+}
+
+func (this *Camunda) UnsetUserMoving(userId string) (err error) {
+	return this.shards.UnsetUserMoving(userId)
+}
+
+// LockUserDeployments prevents a rebalance of the user until unlock is called;
+// fails with ErrUserMoving while the deployments of the user are moved to another shard
+func (this *Camunda) LockUserDeployments(userId string) (unlock func(), err error) {
+	//checked before the lock too, to not wait for the lock held by a running rebalance
+	moving, err := this.shards.IsUserMoving(userId)
+	if err != nil {
+		return func() {}, err
+	}
+	if moving {
+		return func() {}, ErrUserMoving
+	}
+	unlock, err = this.shards.LockUserShared(userId)
+	if err != nil {
+		return unlock, err
+	}
+	moving, err = this.shards.IsUserMoving(userId)
+	if err == nil && moving {
+		err = ErrUserMoving
+	}
+	if err != nil {
+		unlock()
+		return func() {}, err
+	}
+	return unlock, nil
+}
+
+// WaitForUserDeployments waits until running deployments of the user are finished (see LockUserDeployments)
+// and prevents new deployments until unlock is called
+func (this *Camunda) WaitForUserDeployments(ctx context.Context, userId string) (unlock func(), err error) {
+	return this.shards.LockUserExclusive(ctx, userId)
+}
+
+// TryLockUserRebalance returns locked=false if a rebalance of the user is already running
+func (this *Camunda) TryLockUserRebalance(userId string) (unlock func(), locked bool, err error) {
+	return this.shards.TryLockUserRebalance(userId)
+}
+
+// ensureShardForStart returns the shard of the user like EnsureShardForUser,
+// but fails with ErrUserMoving while the deployments of the user are moved to another shard
+func (this *Camunda) ensureShardForStart(userId string) (shard string, err error) {
+	moving, err := this.shards.IsUserMoving(userId)
+	if err != nil {
+		return shard, err
+	}
+	if moving {
+		return shard, ErrUserMoving
+	}
+	return this.shards.EnsureShardForUser(userId)
+}
+
+func (this *Camunda) GetShards() (result []string, err error) {
+	return this.shards.GetShards()
+}
+
+// selects the shard with the fewest users, ignoring the excluded shards
+func (this *Camunda) SelectShardExcluding(excluded ...string) (shard string, err error) {
+	return this.shards.SelectShardExcluding(excluded...)
+}
+
+// returns deployments of the user without replacing the deployment id with the virtual id
+func (this *Camunda) GetRawDeploymentListForShard(shard string, userId string) (result model.CamundaDeployments, err error) {
+	err = Get(shard+"/engine-rest/deployment?tenantIdIn="+url.QueryEscape(userId), &result)
+	return
+}
+
+func (this *Camunda) GetRawDefinitionsByDeploymentForShard(shard string, deploymentId string) (result model.ProcessDefinitions, err error) {
+	err = Get(shard+"/engine-rest/process-definition?deploymentId="+url.QueryEscape(deploymentId), &result)
+	return
+}
+
+func (this *Camunda) GetProcessInstanceCountForShard(shard string, userId string) (result model.Count, err error) {
+	err = Get(shard+"/engine-rest/process-instance/count?tenantIdIn="+url.QueryEscape(userId), &result)
+	return
+}
+
+// removes all running process-instances of the user in the given shard
+func (this *Camunda) RemoveProcessInstancesForShard(shard string, userId string) (count int, err error) {
+	instances := model.ProcessInstances{}
+	err = Get(shard+"/engine-rest/process-instance?tenantIdIn="+url.QueryEscape(userId), &instances)
+	if err != nil {
+		return count, err
+	}
+	for _, instance := range instances {
+		if this.processIo != nil {
+			err = this.processIo.DeleteProcessInstance(instance.Id)
+			if err != nil {
+				return count, err
+			}
+		}
+		err = removeProcessInstanceForShard(instance.Id, shard)
+		if err != nil {
+			return count, err
+		}
+		this.publishInstanceEvent(shard, model.ProcessInstanceEventDeleted, instance, userId)
+		count++
+	}
+	return count, nil
+}
+
+// returns the bpmn and svg files stored by DeployProcess in the given deployment
+func (this *Camunda) GetDeploymentFilesForShard(shard string, deploymentId string) (xml string, svg string, err error) {
+	resources := model.DeploymentResources{}
+	err = Get(shard+"/engine-rest/deployment/"+url.QueryEscape(deploymentId)+"/resources", &resources)
+	if err != nil {
+		return xml, svg, err
+	}
+	for _, resource := range resources {
+		switch {
+		case strings.HasSuffix(resource.Name, ".bpmn"):
+			xml, err = getDeploymentResourceData(shard, deploymentId, resource.Id)
+		case strings.HasSuffix(resource.Name, ".svg"):
+			svg, err = getDeploymentResourceData(shard, deploymentId, resource.Id)
+		}
+		if err != nil {
+			return xml, svg, err
+		}
+	}
+	if xml == "" {
+		return xml, svg, errors.New("missing bpmn resource in deployment " + deploymentId)
+	}
+	return xml, svg, nil
+}
+
+func getDeploymentResourceData(shard string, deploymentId string, resourceId string) (result string, err error) {
+	resp, err := http.Get(shard + "/engine-rest/deployment/" + url.QueryEscape(deploymentId) + "/resources/" + url.QueryEscape(resourceId) + "/data")
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	temp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+	if resp.StatusCode >= 300 {
+		return result, errors.New(resp.Status + " " + string(temp))
+	}
+	return string(temp), nil
+}
+
+// removes user info from shard urls to be used in logs and responses
+func AnonymizeShard(shard string) string {
+	u, err := url.Parse(shard)
+	if err != nil {
+		return ""
+	}
+	u.User = nil
+	return u.String()
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package camunda
+
+import "net/url"
+
+func (this *Camunda) SetProcessInstanceSuspended(id string, userId string, suspended bool) error {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return err
+	}
+	//"/engine-rest/process-instance/" + id + "/suspended"
+	return put(shard+"/engine-rest/process-instance/"+url.QueryEscape(id)+"/suspended", map[string]interface{}{
+		"suspended": suspended,
+	})
+}
+
+// SetDeploymentSuspended suspends or activates all process-definitions of the deployment and their process-instances.
+// suspended definitions can not be started.
+func (this *Camunda) SetDeploymentSuspended(vid string, userId string, suspended bool) error {
+	definitions, err := this.GetDefinitionByDeploymentVid(vid, userId)
+	if err != nil {
+		return err
+	}
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return err
+	}
+	for _, definition := range definitions {
+		//"/engine-rest/process-definition/" + id + "/suspended"
+		err = put(shard+"/engine-rest/process-definition/"+url.QueryEscape(definition.Id)+"/suspended", map[string]interface{}{
+			"suspended":               suspended,
+			"includeProcessInstances": true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package camunda
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+var ErrVariableNotFound = errors.New("variable not found")
+var ErrInvalidVariable = errors.New("invalid variable")
+
+// camunda serializes dates as 2006-01-02T15:04:05.000-0700
+const camundaDateFormat = "2006-01-02T15:04:05.000-0700"
+
+func (this *Camunda) GetProcessInstanceVariables(id string, userId string) (result model.VariableMap, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	//"/engine-rest/process-instance/" + id + "/variables"
+	err = Get(shard+"/engine-rest/process-instance/"+url.QueryEscape(id)+"/variables?deserializeValues=false", &result)
+	if err != nil {
+		return result, err
+	}
+	for name, variable := range result {
+		result[name] = NormalizeVariable(variable)
+	}
+	return result, nil
+}
+
+// UpdateProcessInstanceVariables applies all modifications and deletions in one engine call.
+// values are serialized with SerializeVariable before they are sent to the engine.
+func (this *Camunda) UpdateProcessInstanceVariables(id string, userId string, update model.VariableModifications) error {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return err
+	}
+	msg := model.VariableModifications{
+		Modifications: model.VariableMap{},
+		Deletions:     update.Deletions,
+	}
+	for name, variable := range update.Modifications {
+		msg.Modifications[name], err = SerializeVariable(variable)
+		if err != nil {
+			return fmt.Errorf("%w: %v: %v", ErrInvalidVariable, name, err.Error())
+		}
+	}
+	//"/engine-rest/process-instance/" + id + "/variables"
+	return post(shard+"/engine-rest/process-instance/"+url.QueryEscape(id)+"/variables", msg)
+}
+
+func (this *Camunda) GetProcessInstanceVariable(id string, userId string, name string) (result model.Variable, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	//"/engine-rest/process-instance/" + id + "/variables/" + name
+	resp, err := http.Get(shard + "/engine-rest/process-instance/" + url.QueryEscape(id) + "/variables/" + url.PathEscape(name) + "?deserializeValue=false")
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		_, _ = io.ReadAll(resp.Body)
+		return result, ErrVariableNotFound
+	}
+	if resp.StatusCode >= 300 {
+		temp, _ := io.ReadAll(resp.Body)
+		return result, errors.New(resp.Status + " " + string(temp))
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return result, err
+	}
+	return NormalizeVariable(result), nil
+}
+
+// NormalizeVariable converts serialized variable values to plain json values:
+//   - Json values and Object values with the serialization format application/json are parsed
+//   - Date values are formatted as RFC3339
+//
+// other values are returned unchanged
+func NormalizeVariable(variable model.Variable) model.Variable {
+	str, isString := variable.Value.(string)
+	if !isString {
+		return variable
+	}
+	switch variable.Type {
+	case "Json":
+		var value interface{}
+		if json.Unmarshal([]byte(str), &value) == nil {
+			variable.Value = value
+		}
+	case "Object":
+		info, _ := variable.ValueInfo.(map[string]interface{})
+		if format, _ := info["serializationDataFormat"].(string); format == "application/json" {
+			var value interface{}
+			if json.Unmarshal([]byte(str), &value) == nil {
+				variable.Value = value
+			}
+		}
+	case "Date":
+		t, err := time.Parse(camundaDateFormat, str)
+		if err == nil {
+			variable.Value = t.Format(time.RFC3339Nano)
+		}
+	}
+	return variable
+}
+
+// SerializeVariable is the counterpart of NormalizeVariable, so that values returned by GET may be sent back unchanged:
+//   - Json values and Object values with the serialization format application/json are always serialized to strings;
+//     a string value is serialized as json string, not interpreted as already serialized json
+//   - Date values formatted as RFC3339 are converted to the date format of the engine
+//   - untyped objects and lists are sent as Json values instead of letting the engine guess the type
+func SerializeVariable(variable model.Variable) (model.Variable, error) {
+	switch variable.Value.(type) {
+	case map[string]interface{}, []interface{}:
+		if variable.Type == "" {
+			variable.Type = "Json"
+		}
+	}
+	if variable.Value == nil {
+		return variable, nil
+	}
+	switch variable.Type {
+	case "Json":
+	case "Object":
+		info, _ := variable.ValueInfo.(map[string]interface{})
+		if format, _ := info["serializationDataFormat"].(string); format != "application/json" {
+			return variable, errors.New("object values are only supported with serializationDataFormat application/json")
+		}
+	case "Date":
+		if str, isString := variable.Value.(string); isString {
+			t, err := time.Parse(time.RFC3339Nano, str)
+			if err == nil {
+				variable.Value = t.Format(camundaDateFormat)
+			}
+		}
+		return variable, nil
+	default:
+		return variable, nil
+	}
+	temp, err := json.Marshal(variable.Value)
+	if err != nil {
+		return variable, err
+	}
+	variable.Value = string(temp)
+	return variable, nil
+}
@@ -17,12 +17,15 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
 )
@@ -40,13 +43,49 @@ type Deployment = model.Deployment
 type Diagram = model.Diagram
 type IncidentHandling = model.IncidentHandling
 type ProcessInstance = model.ProcessInstance
+type ProcessInstances = model.ProcessInstances
+type ProcessInstancesWithTotal = model.ProcessInstancesWithTotal
 type HistoricProcessInstances = model.HistoricProcessInstances
 type HistoricProcessInstancesWithTotal = model.HistoricProcessInstancesWithTotal
 type ExtendedDeployment = model.ExtendedDeployment
+type RebalanceRequest = model.RebalanceRequest
+type SuspensionState = model.SuspensionState
+type Variable = model.Variable
+type VariableMap = model.VariableMap
+type VariableModifications = model.VariableModifications
+type ActivityInstance = model.ActivityInstance
+type HistoricActivityInstances = model.HistoricActivityInstances
+type ProcessInstanceModification = model.ProcessInstanceModification
+type ModificationInstruction = model.ModificationInstruction
+type RestartRequest = model.RestartRequest
+type RebalanceResult = model.RebalanceResult
+type DeploymentVersion = model.DeploymentVersion
+type DeploymentValidationResult = model.DeploymentValidationResult
+type DeploymentJob = model.DeploymentJob
+type JsonSchema = model.JsonSchema
+type StartParameterError = model.StartParameterError
+type StartRequest = model.StartRequest
+type BatchStartItem = model.BatchStartItem
+type BatchStartResult = model.BatchStartResult
+type ProcessInstanceWithVariables = model.ProcessInstanceWithVariables
+type StartSchedule = model.StartSchedule
+type ProcessInstanceEvent = model.ProcessInstanceEvent
+type Webhook = model.Webhook
+type WebhookDelivery = model.WebhookDelivery
+type WebhookPayload = model.WebhookPayload
+type Incident = model.Incident
+type Incidents = model.Incidents
+type ExternalTaskFetchRequest = model.ExternalTaskFetchRequest
+type ExternalTaskTopic = model.ExternalTaskTopic
+type LockedExternalTask = model.LockedExternalTask
+type ExternalTaskCompleteRequest = model.ExternalTaskCompleteRequest
+type ExternalTaskFailureRequest = model.ExternalTaskFailureRequest
+type ExternalTaskExtendLockRequest = model.ExternalTaskExtendLockRequest
 
 type StartOptions struct {
-	BusinessKey string
-	Inputs      map[string]interface{}
+	BusinessKey    string
+	Inputs         map[string]interface{}
+	IdempotencyKey string
 }
 
 type InstanceListOptions struct {
@@ -70,6 +109,25 @@ func (this *Client) Deploy(token string, depl DeploymentMessage) (err error, cod
 	return doVoid(token, req)
 }
 
+// DeployAsync queues the deployment; the returned job may be polled with GetDeploymentJob.
+// if callbackUrl is set, the finished job is posted to it.
+func (this *Client) DeployAsync(token string, depl DeploymentMessage, callbackUrl string) (result DeploymentJob, err error, code int) {
+	body, err := json.Marshal(depl)
+	if err != nil {
+		return result, err, 0
+	}
+	query := url.Values{}
+	query.Set("async", "true")
+	if callbackUrl != "" {
+		query.Set("callback_url", callbackUrl)
+	}
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%v/process-deployments?%v", this.serverUrl, query.Encode()), bytes.NewBuffer(body))
+	if err != nil {
+		return result, err, 0
+	}
+	return do[DeploymentJob](token, req)
+}
+
 func (this *Client) ListDeployments(token string, options DeploymentListOptions) (result []ExtendedDeployment, err error, code int) {
 	query := url.Values{}
 	for key, val := range options.OtherArgs {
@@ -98,6 +156,37 @@ func (this *Client) GetProcessInstances(token string) (result HistoricProcessIns
 	return do[HistoricProcessInstances](token, req)
 }
 
+func (this *Client) ListProcessInstances(token string, options InstanceListOptions) (result ProcessInstances, err error, code int) {
+	query := url.Values{}
+	if options.BusinessKey != "" {
+		query.Add("business_key", options.BusinessKey)
+	}
+	for key, val := range options.OtherArgs {
+		query.Add(key, val)
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/process-instances?%v", this.serverUrl, query.Encode()), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[ProcessInstances](token, req)
+}
+
+func (this *Client) ListProcessInstancesWithTotal(token string, options InstanceListOptions) (result ProcessInstancesWithTotal, err error, code int) {
+	query := url.Values{}
+	query.Add("with_total", "true")
+	if options.BusinessKey != "" {
+		query.Add("business_key", options.BusinessKey)
+	}
+	for key, val := range options.OtherArgs {
+		query.Add(key, val)
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/process-instances?%v", this.serverUrl, query.Encode()), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[ProcessInstancesWithTotal](token, req)
+}
+
 func (this *Client) GetHistoricProcessInstances(token string, options InstanceListOptions) (result HistoricProcessInstances, err error, code int) {
 	query := url.Values{}
 	if options.BusinessKey != "" {
@@ -142,9 +231,56 @@ func (this *Client) StartDeployment(token string, deplId string, options StartOp
 	if err != nil {
 		return result, err, 0
 	}
+	if options.IdempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", options.IdempotencyKey)
+	}
 	return do[ProcessInstance](token, req)
 }
 
+func (this *Client) StartDeploymentWithRequest(token string, deplId string, request StartRequest) (result ProcessInstanceWithVariables, err error, code int) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return result, err, 0
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%v/v2/deployments/%v/start", this.serverUrl, url.PathEscape(deplId)), bytes.NewBuffer(body))
+	if err != nil {
+		return result, err, 0
+	}
+	return do[ProcessInstanceWithVariables](token, req)
+}
+
+func (this *Client) StartDeploymentBatch(token string, deplId string, items []BatchStartItem) (result []BatchStartResult, err error, code int) {
+	body, err := json.Marshal(items)
+	if err != nil {
+		return result, err, 0
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%v/v2/deployments/%v/start-batch", this.serverUrl, url.PathEscape(deplId)), bytes.NewBuffer(body))
+	if err != nil {
+		return result, err, 0
+	}
+	return do[[]BatchStartResult](token, req)
+}
+
+func (this *Client) StartProcessDefinitionWithRequest(token string, definitionId string, request StartRequest) (result ProcessInstanceWithVariables, err error, code int) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return result, err, 0
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%v/v2/process-definitions/%v/start", this.serverUrl, url.PathEscape(definitionId)), bytes.NewBuffer(body))
+	if err != nil {
+		return result, err, 0
+	}
+	return do[ProcessInstanceWithVariables](token, req)
+}
+
+func (this *Client) GetDeploymentParameterSchema(token string, deplId string) (result JsonSchema, err error, code int) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/deployments/%v/parameter-schema", this.serverUrl, url.PathEscape(deplId)), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[JsonSchema](token, req)
+}
+
 func (this *Client) DeleteProcessInstancesByBusinessKey(token string, businessKey string) (err error, code int) {
 	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%v/v2/process-instances-by-business-key/%v", this.serverUrl, url.PathEscape(businessKey)), nil)
 	if err != nil {
@@ -153,6 +289,371 @@ func (this *Client) DeleteProcessInstancesByBusinessKey(token string, businessKe
 	return doVoid(token, req)
 }
 
+func (this *Client) Rebalance(token string, request RebalanceRequest) (result RebalanceResult, err error, code int) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return result, err, 0
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%v/shards/rebalance", this.serverUrl), bytes.NewBuffer(body))
+	if err != nil {
+		return result, err, 0
+	}
+	return do[RebalanceResult](token, req)
+}
+
+func (this *Client) SetProcessInstanceSuspended(token string, instanceId string, suspended bool) (err error, code int) {
+	body, err := json.Marshal(SuspensionState{Suspended: suspended})
+	if err != nil {
+		return err, 0
+	}
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%v/v2/process-instances/%v/suspended", this.serverUrl, url.PathEscape(instanceId)), bytes.NewBuffer(body))
+	if err != nil {
+		return err, 0
+	}
+	return doVoid(token, req)
+}
+
+func (this *Client) SetDeploymentSuspended(token string, deplId string, suspended bool) (err error, code int) {
+	body, err := json.Marshal(SuspensionState{Suspended: suspended})
+	if err != nil {
+		return err, 0
+	}
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%v/v2/deployments/%v/suspended", this.serverUrl, url.PathEscape(deplId)), bytes.NewBuffer(body))
+	if err != nil {
+		return err, 0
+	}
+	return doVoid(token, req)
+}
+
+func (this *Client) GetDeploymentVersions(token string, deplId string) (result []DeploymentVersion, err error, code int) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/deployments/%v/versions", this.serverUrl, url.PathEscape(deplId)), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[[]DeploymentVersion](token, req)
+}
+
+func (this *Client) RollbackDeployment(token string, deplId string, version int) (err error, code int) {
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%v/v2/deployments/%v/rollback?version=%v", this.serverUrl, url.PathEscape(deplId), version), nil)
+	if err != nil {
+		return err, 0
+	}
+	return doVoid(token, req)
+}
+
+func (this *Client) ValidateDeployment(token string, depl Deployment) (result DeploymentValidationResult, err error, code int) {
+	body, err := json.Marshal(depl)
+	if err != nil {
+		return result, err, 0
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%v/v2/deployments/validate", this.serverUrl), bytes.NewBuffer(body))
+	if err != nil {
+		return result, err, 0
+	}
+	return do[DeploymentValidationResult](token, req)
+}
+
+func (this *Client) GetDeploymentJob(token string, jobId string) (result DeploymentJob, err error, code int) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/deployment-jobs/%v", this.serverUrl, url.PathEscape(jobId)), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[DeploymentJob](token, req)
+}
+
+func (this *Client) GetProcessInstanceVariables(token string, instanceId string) (result VariableMap, err error, code int) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/process-instances/%v/variables", this.serverUrl, url.PathEscape(instanceId)), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[VariableMap](token, req)
+}
+
+func (this *Client) GetProcessInstanceVariable(token string, instanceId string, name string) (result Variable, err error, code int) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/process-instances/%v/variables/%v", this.serverUrl, url.PathEscape(instanceId), url.PathEscape(name)), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[Variable](token, req)
+}
+
+func (this *Client) UpdateProcessInstanceVariables(token string, instanceId string, update VariableModifications) (err error, code int) {
+	body, err := json.Marshal(update)
+	if err != nil {
+		return err, 0
+	}
+	req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%v/v2/process-instances/%v/variables", this.serverUrl, url.PathEscape(instanceId)), bytes.NewBuffer(body))
+	if err != nil {
+		return err, 0
+	}
+	return doVoid(token, req)
+}
+
+func (this *Client) GetProcessInstanceActivities(token string, instanceId string) (result ActivityInstance, err error, code int) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/process-instances/%v/activities", this.serverUrl, url.PathEscape(instanceId)), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[ActivityInstance](token, req)
+}
+
+func (this *Client) GetHistoricProcessInstanceActivities(token string, instanceId string) (result HistoricActivityInstances, err error, code int) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/history/process-instances/%v/activities", this.serverUrl, url.PathEscape(instanceId)), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[HistoricActivityInstances](token, req)
+}
+
+func (this *Client) ModifyProcessInstance(token string, instanceId string, modification ProcessInstanceModification) (err error, code int) {
+	body, err := json.Marshal(modification)
+	if err != nil {
+		return err, 0
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%v/v2/process-instances/%v/modification", this.serverUrl, url.PathEscape(instanceId)), bytes.NewBuffer(body))
+	if err != nil {
+		return err, 0
+	}
+	return doVoid(token, req)
+}
+
+func (this *Client) RestartHistoricProcessInstance(token string, instanceId string, request RestartRequest) (err error, code int) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err, 0
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%v/v2/history/process-instances/%v/restart", this.serverUrl, url.PathEscape(instanceId)), bytes.NewBuffer(body))
+	if err != nil {
+		return err, 0
+	}
+	return doVoid(token, req)
+}
+
+func (this *Client) ListDeploymentSchedules(token string, deplId string) (result []StartSchedule, err error, code int) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/deployments/%v/schedules", this.serverUrl, url.PathEscape(deplId)), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[[]StartSchedule](token, req)
+}
+
+func (this *Client) CreateDeploymentSchedule(token string, deplId string, schedule StartSchedule) (result StartSchedule, err error, code int) {
+	body, err := json.Marshal(schedule)
+	if err != nil {
+		return result, err, 0
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%v/v2/deployments/%v/schedules", this.serverUrl, url.PathEscape(deplId)), bytes.NewBuffer(body))
+	if err != nil {
+		return result, err, 0
+	}
+	return do[StartSchedule](token, req)
+}
+
+func (this *Client) GetSchedule(token string, id string) (result StartSchedule, err error, code int) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/schedules/%v", this.serverUrl, url.PathEscape(id)), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[StartSchedule](token, req)
+}
+
+func (this *Client) UpdateSchedule(token string, id string, schedule StartSchedule) (result StartSchedule, err error, code int) {
+	body, err := json.Marshal(schedule)
+	if err != nil {
+		return result, err, 0
+	}
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%v/v2/schedules/%v", this.serverUrl, url.PathEscape(id)), bytes.NewBuffer(body))
+	if err != nil {
+		return result, err, 0
+	}
+	return do[StartSchedule](token, req)
+}
+
+func (this *Client) DeleteSchedule(token string, id string) (err error, code int) {
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%v/v2/schedules/%v", this.serverUrl, url.PathEscape(id)), nil)
+	if err != nil {
+		return err, 0
+	}
+	return doVoid(token, req)
+}
+
+// SubscribeProcessInstanceEvents streams the process-instance events of the user until ctx is done or the server ends the stream; the channel is closed afterward.
+// query may contain the filters deployment_id and types.
+func (this *Client) SubscribeProcessInstanceEvents(ctx context.Context, token string, query url.Values) (result <-chan ProcessInstanceEvent, err error, code int) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%v/v2/process-instances/events?%v", this.serverUrl, query.Encode()), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	req.Header.Set("Authorization", token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		temp, _ := io.ReadAll(resp.Body)
+		return result, fmt.Errorf("unexpected statuscode %v: %v", resp.StatusCode, string(temp)), resp.StatusCode
+	}
+	events := make(chan ProcessInstanceEvent)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+			if !ok {
+				continue
+			}
+			event := ProcessInstanceEvent{}
+			if json.Unmarshal([]byte(data), &event) != nil {
+				continue
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil, resp.StatusCode
+}
+
+func (this *Client) ListWebhooks(token string) (result []Webhook, err error, code int) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/webhooks", this.serverUrl), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[[]Webhook](token, req)
+}
+
+func (this *Client) CreateWebhook(token string, webhook Webhook) (result Webhook, err error, code int) {
+	body, err := json.Marshal(webhook)
+	if err != nil {
+		return result, err, 0
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%v/v2/webhooks", this.serverUrl), bytes.NewBuffer(body))
+	if err != nil {
+		return result, err, 0
+	}
+	return do[Webhook](token, req)
+}
+
+func (this *Client) GetWebhook(token string, id string) (result Webhook, err error, code int) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/webhooks/%v", this.serverUrl, url.PathEscape(id)), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[Webhook](token, req)
+}
+
+func (this *Client) UpdateWebhook(token string, id string, webhook Webhook) (result Webhook, err error, code int) {
+	body, err := json.Marshal(webhook)
+	if err != nil {
+		return result, err, 0
+	}
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%v/v2/webhooks/%v", this.serverUrl, url.PathEscape(id)), bytes.NewBuffer(body))
+	if err != nil {
+		return result, err, 0
+	}
+	return do[Webhook](token, req)
+}
+
+func (this *Client) DeleteWebhook(token string, id string) (err error, code int) {
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%v/v2/webhooks/%v", this.serverUrl, url.PathEscape(id)), nil)
+	if err != nil {
+		return err, 0
+	}
+	return doVoid(token, req)
+}
+
+func (this *Client) ListWebhookDeliveries(token string, id string, limit int, offset int) (result []WebhookDelivery, err error, code int) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/webhooks/%v/deliveries?limit=%v&offset=%v", this.serverUrl, url.PathEscape(id), limit, offset), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[[]WebhookDelivery](token, req)
+}
+
+func (this *Client) ListIncidents(token string, query url.Values) (result Incidents, err error, code int) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/incidents?%v", this.serverUrl, query.Encode()), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[Incidents](token, req)
+}
+
+func (this *Client) GetProcessInstanceIncidents(token string, instanceId string) (result Incidents, err error, code int) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/process-instances/%v/incidents", this.serverUrl, url.PathEscape(instanceId)), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[Incidents](token, req)
+}
+
+func (this *Client) ResolveIncident(token string, incidentId string) (err error, code int) {
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%v/v2/incidents/%v/resolve", this.serverUrl, url.PathEscape(incidentId)), nil)
+	if err != nil {
+		return err, 0
+	}
+	return doVoid(token, req)
+}
+
+func (this *Client) GetDeploymentIncidentHandling(token string, deploymentId string) (result IncidentHandling, err error, code int) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/v2/deployments/%v/incident-handling", this.serverUrl, url.PathEscape(deploymentId)), nil)
+	if err != nil {
+		return result, err, 0
+	}
+	return do[IncidentHandling](token, req)
+}
+
+func (this *Client) SetDeploymentIncidentHandling(token string, deploymentId string, handling IncidentHandling) (err error, code int) {
+	body, err := json.Marshal(handling)
+	if err != nil {
+		return err, 0
+	}
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%v/v2/deployments/%v/incident-handling", this.serverUrl, url.PathEscape(deploymentId)), bytes.NewBuffer(body))
+	if err != nil {
+		return err, 0
+	}
+	return doVoid(token, req)
+}
+
+func (this *Client) FetchAndLockExternalTasks(token string, request ExternalTaskFetchRequest) (result []LockedExternalTask, err error, code int) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return result, err, 0
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%v/v2/external-tasks/fetch-and-lock", this.serverUrl), bytes.NewBuffer(body))
+	if err != nil {
+		return result, err, 0
+	}
+	return do[[]LockedExternalTask](token, req)
+}
+
+func (this *Client) CompleteExternalTask(token string, taskId string, request ExternalTaskCompleteRequest) (err error, code int) {
+	return this.sendExternalTaskRequest(token, taskId, "complete", request)
+}
+
+func (this *Client) HandleExternalTaskFailure(token string, taskId string, request ExternalTaskFailureRequest) (err error, code int) {
+	return this.sendExternalTaskRequest(token, taskId, "failure", request)
+}
+
+func (this *Client) ExtendExternalTaskLock(token string, taskId string, request ExternalTaskExtendLockRequest) (err error, code int) {
+	return this.sendExternalTaskRequest(token, taskId, "extend-lock", request)
+}
+
+func (this *Client) sendExternalTaskRequest(token string, taskId string, action string, request interface{}) (err error, code int) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err, 0
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%v/v2/external-tasks/%v/%v", this.serverUrl, url.PathEscape(taskId), action), bytes.NewBuffer(body))
+	if err != nil {
+		return err, 0
+	}
+	return doVoid(token, req)
+}
+
 func do[T any](token string, req *http.Request) (result T, err error, code int) {
 	req.Header.Set("Authorization", token)
 	resp, err := http.DefaultClient.Do(req)
@@ -55,6 +55,51 @@ type Config struct {
 	AuthClientId     string `json:"auth_client_id" config:"secret"`
 	AuthClientSecret string `json:"auth_client_secret" config:"secret"`
 
+	JwksUrl           string `json:"jwks_url"`
+	JwksCacheDuration string `json:"jwks_cache_duration"`
+	JwtIssuer         string `json:"jwt_issuer"`
+	JwtAudience       string `json:"jwt_audience"`
+	JwtLeeway         string `json:"jwt_leeway"`
+
+	DeploymentVersionsKept    int64  `json:"deployment_versions_kept"`
+	DeploymentJobStaleTimeout string `json:"deployment_job_stale_timeout"`
+	DeploymentJobRetention    string `json:"deployment_job_retention"`
+	DeploymentWorkers         int64  `json:"deployment_workers"`
+	DeploymentQueueSize       int64  `json:"deployment_queue_size"`
+
+	IdempotencyWindow string `json:"idempotency_window"`
+
+	StartParameterValidation bool  `json:"start_parameter_validation"`
+	StartBatchMaxSize        int64 `json:"start_batch_max_size"`
+	StartBatchConcurrency    int64 `json:"start_batch_concurrency"`
+
+	ScheduleCheckInterval string `json:"schedule_check_interval"`
+
+	InstanceEventPollInterval string `json:"instance_event_poll_interval"`
+
+	WebhookDeliveryInterval    string `json:"webhook_delivery_interval"`
+	WebhookTimeout             string `json:"webhook_timeout"`
+	WebhookRetryBackoff        string `json:"webhook_retry_backoff"`
+	WebhookMaxAttempts         int64  `json:"webhook_max_attempts"`
+	WebhookDeliveryRetention   string `json:"webhook_delivery_retention"`
+	WebhookAllowPrivateTargets bool   `json:"webhook_allow_private_targets"`
+
+	KafkaUrl         string `json:"kafka_url"`
+	KafkaChangeTopic string `json:"kafka_change_topic"`
+
+	ExternalTaskMaxPollTimeout string `json:"external_task_max_poll_timeout"`
+
+	ScriptPolicy          string            `json:"script_policy"`
+	TenantScriptPolicies  map[string]string `json:"tenant_script_policies"`
+	ScriptAllowedFormats  []string          `json:"script_allowed_formats"`
+	ScriptForbiddenTokens []string          `json:"script_forbidden_tokens"`
+
+	ImplementationCheck        string   `json:"implementation_check"`
+	AllowedDelegateClasses     []string `json:"allowed_delegate_classes"`
+	AllowedDelegateExpressions []string `json:"allowed_delegate_expressions"`
+	AllowedExpressions         []string `json:"allowed_expressions"`
+	AllowedConnectors          []string `json:"allowed_connectors"`
+
 	AccessLogTrimFormat string `json:"access_log_trim_format"`
 
 	LogLevel string       `json:"log_level"`
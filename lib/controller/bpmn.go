@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/etree"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
 )
 
 func SecureProcessScripts(xml string) (result string, err error) {
@@ -76,3 +77,104 @@ func SetProcessId(xml string, id string) (result string, err error) {
 	}
 	return doc.WriteToString()
 }
+
+// ImplementationAllowList lists the allowed values of camunda:class, camunda:delegateExpression, camunda:expression and camunda:connectorId.
+// an entry matches the exact value or, if it ends with '*', every value with the prefix before the '*'.
+type ImplementationAllowList struct {
+	Classes             []string
+	DelegateExpressions []string
+	Expressions         []string
+	Connectors          []string
+}
+
+// CheckImplementations finds java delegates, expressions and connectors not allowed by allowList.
+// elements and attributes are matched by the camunda namespace uri, independent of the prefix used in the xml.
+// if strip is true, execution and task listeners with a not allowed implementation are removed from the result and reported as stripped;
+// all other not allowed implementations (service tasks, message events, connectors) are part of the process flow and reported as rejected.
+func CheckImplementations(xml string, allowList ImplementationAllowList, strip bool) (result string, stripped []model.ValidationMessage, rejected []model.ValidationMessage, err error) {
+	defer func() {
+		if r := recover(); r != nil && err == nil {
+			err = errors.New(fmt.Sprint("Recovered Error: ", r))
+			slog.Error("recover from panic in CheckImplementations", "error", err, "stack", string(debug.Stack()))
+		}
+	}()
+	root, err := parseBpmnElements(xml)
+	if err != nil {
+		return result, stripped, rejected, err
+	}
+	doc := etree.NewDocument()
+	err = doc.ReadFromString(xml)
+	if err != nil {
+		return result, stripped, rejected, err
+	}
+	//both trees contain the same elements in document order; etree is used to remove stripped listeners
+	elements := []*bpmnElement{}
+	root.walk(func(element *bpmnElement) {
+		elements = append(elements, element)
+	})
+	etreeElements := []*etree.Element{}
+	var walkEtree func(element *etree.Element)
+	walkEtree = func(element *etree.Element) {
+		etreeElements = append(etreeElements, element)
+		for _, child := range element.ChildElements() {
+			walkEtree(child)
+		}
+	}
+	if doc.Root() != nil {
+		walkEtree(doc.Root())
+	}
+	if len(elements) != len(etreeElements) {
+		return result, stripped, rejected, errors.New("unable to match bpmn elements")
+	}
+
+	allowed := map[string][]string{
+		"class":              allowList.Classes,
+		"delegateExpression": allowList.DelegateExpressions,
+		"expression":         allowList.Expressions,
+	}
+	for i, element := range elements {
+		isListener := element.is(camundaNamespace, "executionListener") || element.is(camundaNamespace, "taskListener")
+		for _, attr := range element.Attr {
+			//listeners use attributes without namespace
+			if attr.Name.Space != camundaNamespace && !(isListener && attr.Name.Space == "") {
+				continue
+			}
+			if _, isImplementation := allowed[attr.Name.Local]; !isImplementation || implementationIsAllowed(allowed[attr.Name.Local], attr.Value) {
+				continue
+			}
+			msg := element.message(fmt.Sprintf("%v %q is not allowed", attr.Name.Local, attr.Value))
+			if strip && isListener && etreeElements[i].Parent() != nil {
+				etreeElements[i].Parent().RemoveChild(etreeElements[i])
+				msg.Message = fmt.Sprintf("camunda:%v with %v %q removed", element.Name.Local, attr.Name.Local, attr.Value)
+				stripped = append(stripped, msg)
+				break
+			}
+			rejected = append(rejected, msg)
+		}
+		if element.is(camundaNamespace, "connector") {
+			connectorId := ""
+			for _, child := range element.Children {
+				if child.is(camundaNamespace, "connectorId") {
+					connectorId = strings.TrimSpace(child.Text)
+				}
+			}
+			if !implementationIsAllowed(allowList.Connectors, connectorId) {
+				rejected = append(rejected, element.message(fmt.Sprintf("camunda:connector %q is not allowed", connectorId)))
+			}
+		}
+	}
+	result, err = doc.WriteToString()
+	return result, stripped, rejected, err
+}
+
+func implementationIsAllowed(allowList []string, value string) bool {
+	for _, entry := range allowList {
+		if prefix, isPrefix := strings.CutSuffix(entry, "*"); isPrefix && strings.HasPrefix(value, prefix) {
+			return true
+		}
+		if entry == value {
+			return true
+		}
+	}
+	return false
+}
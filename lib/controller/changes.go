@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+const changeEventQueueSize = 1000
+
+// Publisher sends change events to downstream services
+type Publisher interface {
+	Publish(event model.ChangeEvent) error
+}
+
+// StartChangePublication forwards change events to the publisher passed to New.
+// deployment events are emitted by the controller; instance starts and deletions are received from the camunda events of this wrapper instance.
+func (this *Controller) StartChangePublication(ctx context.Context) error {
+	if this.publisher == nil {
+		return nil
+	}
+	//instance starts and deletions are published by the wrapper instance handling the request; no tenant needs to be polled
+	this.camunda.Events().ListenTenants(func() []string { return nil }, this.publishInstanceChange)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-this.changes:
+				err := this.publisher.Publish(event)
+				if err != nil {
+					this.config.GetLogger().Error("unable to publish change event", "type", event.Type, "deploymentId", event.DeploymentId, "processInstanceId", event.ProcessInstanceId, "error", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (this *Controller) publishInstanceChange(event model.ProcessInstanceEvent) {
+	change := model.ChangeEvent{
+		UserId:              event.UserId,
+		DeploymentId:        event.DeploymentId,
+		ProcessInstanceId:   event.ProcessInstanceId,
+		ProcessDefinitionId: event.ProcessDefinitionId,
+		BusinessKey:         event.BusinessKey,
+		Time:                event.Time,
+	}
+	switch event.Type {
+	case model.ProcessInstanceEventStarted:
+		change.Type = model.ChangeEventInstanceStarted
+	case model.ProcessInstanceEventDeleted:
+		change.Type = model.ChangeEventInstanceDeleted
+	default:
+		return
+	}
+	this.publishChange(change)
+}
+
+// publishChange queues the event without blocking; events are dropped if no publisher is set or the queue is full
+func (this *Controller) publishChange(event model.ChangeEvent) {
+	if this.publisher == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	select {
+	case this.changes <- event:
+	default:
+		this.config.GetLogger().Warn("change event queue full, drop event", "type", event.Type, "deploymentId", event.DeploymentId, "processInstanceId", event.ProcessInstanceId)
+	}
+}
@@ -19,15 +19,22 @@ package controller
 import (
 	"encoding/xml"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
+	"sync"
 
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/etree"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/camunda"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/idempotency"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/jobs"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/processio"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/schedules"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/vid"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/webhooks"
 	"github.com/SENERGY-Platform/process-incident-api/lib/client"
 )
 
@@ -36,6 +43,20 @@ type Controller struct {
 	camunda   *camunda.Camunda
 	vid       *vid.Vid
 	processIo *processio.ProcessIo
+	jobs      *jobs.Jobs
+
+	idempotency *idempotency.Idempotency
+	schedules   *schedules.Schedules
+	webhooks    *webhooks.Webhooks
+	publisher   Publisher
+
+	webhookDelivery *webhookDelivery
+	changes         chan model.ChangeEvent
+
+	deploymentQueue chan model.DeploymentJob
+
+	scriptPolicies    map[string]ScriptPolicy
+	scriptPoliciesMux sync.RWMutex
 }
 
 func New(config configuration.Config, camunda *camunda.Camunda, vid *vid.Vid, processIo *processio.ProcessIo) *Controller {
@@ -44,116 +65,264 @@ func New(config configuration.Config, camunda *camunda.Camunda, vid *vid.Vid, pr
 		camunda:   camunda,
 		vid:       vid,
 		processIo: processIo,
+
+		changes: make(chan model.ChangeEvent, changeEventQueueSize),
+
+		scriptPolicies: defaultScriptPolicies(config),
 	}
 }
 
+// SetJobs enables the persistence of deployment steps; without jobs, deployment steps are not persisted
+func (this *Controller) SetJobs(jobs *jobs.Jobs) *Controller {
+	this.jobs = jobs
+	return this
+}
+
+// SetIdempotency enables idempotency keys; without it, idempotency keys are ignored
+func (this *Controller) SetIdempotency(idempotency *idempotency.Idempotency) *Controller {
+	this.idempotency = idempotency
+	return this
+}
+
+// SetSchedules enables start schedules; without it, start schedules are not available
+func (this *Controller) SetSchedules(schedules *schedules.Schedules) *Controller {
+	this.schedules = schedules
+	return this
+}
+
+// SetWebhooks enables webhooks; without it, webhooks are not available
+func (this *Controller) SetWebhooks(webhooks *webhooks.Webhooks) *Controller {
+	this.webhooks = webhooks
+	return this
+}
+
+// SetPublisher enables the publication of change events; without it, no change events are published
+func (this *Controller) SetPublisher(publisher Publisher) *Controller {
+	this.publisher = publisher
+	return this
+}
+
 func (this *Controller) Deploy(depl model.DeploymentMessage) (err error, code int) {
+	_, err, code = this.DeployJob(depl)
+	return err, code
+}
+
+// prepareDeployment checks the deployment and replaces the xml with the xml to deploy
+func (this *Controller) prepareDeployment(depl model.DeploymentMessage) (result model.DeploymentMessage, err error, code int) {
 	xml, err := SecureProcessScripts(depl.Diagram.XmlDeployed)
 	if err != nil {
-		return err, http.StatusInternalServerError
+		return depl, err, http.StatusInternalServerError
+	}
+	root, err := parseBpmnElements(depl.Diagram.XmlDeployed)
+	if err != nil {
+		return depl, err, http.StatusBadRequest
+	}
+	if errs := this.checkScripts(depl.UserId, root); len(errs) > 0 {
+		return depl, scriptPolicyError(errs), http.StatusBadRequest
+	}
+	xml, stripped, rejected, err := this.checkImplementations(xml)
+	if err != nil {
+		return depl, err, http.StatusInternalServerError
+	}
+	if len(rejected) > 0 {
+		return depl, violationError("implementation not allowed", rejected), http.StatusBadRequest
+	}
+	for _, msg := range stripped {
+		this.config.GetLogger().Info("strip implementation from deployment", "deploymentId", depl.Id, "elementId", msg.ElementId, "reason", msg.Message)
 	}
 	if depl.Id == "" {
-		return errors.New("no deployment id provided"), http.StatusBadRequest
+		return depl, errors.New("no deployment id provided"), http.StatusBadRequest
 	}
 	if depl.UserId == "" {
-		return errors.New("no user id provided"), http.StatusBadRequest
+		return depl, errors.New("no user id provided"), http.StatusBadRequest
 	}
 	if depl.Diagram.Svg == "" {
-		return errors.New("no svg provided"), http.StatusBadRequest
+		return depl, errors.New("no svg provided"), http.StatusBadRequest
 	}
 	xml, err = SetProcessId(xml, depl.Id)
 	if err != nil {
-		return err, http.StatusInternalServerError
+		return depl, err, http.StatusInternalServerError
 	}
 	if !validateXml(xml) {
-		return errors.New("invalid bpmn"), http.StatusBadRequest
+		return depl, errors.New("invalid bpmn"), http.StatusBadRequest
+	}
+	depl.Diagram.XmlDeployed = xml
+	return depl, nil, http.StatusOK
+}
+
+// setIncidentHandling registers the incident handling of the deployment message and saves it for the vid of the deployment
+func (this *Controller) setIncidentHandling(deploymentId string, depl model.DeploymentMessage) error {
+	if depl.IncidentHandling == nil {
+		return this.vid.RemoveIncidentHandling(depl.Id)
 	}
-	err = this.cleanupExistingDeployment(depl.UserId, depl.Id)
+	definitions, err := this.camunda.GetRawDefinitionsByDeployment(deploymentId, depl.UserId)
 	if err != nil {
-		return err, http.StatusInternalServerError
+		return err
 	}
-
-	this.config.GetLogger().Debug("deploy process", "id", depl.Id, "name", depl.Name, "user", depl.UserId, "xml", xml)
-	deploymentId, err := this.camunda.DeployProcess(depl.Name, xml, depl.Diagram.Svg, depl.UserId, depl.Source)
+	if len(definitions) == 0 {
+		this.config.GetLogger().Warn("no definitions for deployment found --> no incident handling deployed")
+	}
+	err = this.registerIncidentHandling(definitions, *depl.IncidentHandling)
 	if err != nil {
-		this.config.GetLogger().Warn("unable to deploy process to camunda ", "error", err)
-		return err, http.StatusInternalServerError
+		return err
 	}
+	return this.vid.SetIncidentHandling(depl.Id, *depl.IncidentHandling)
+}
 
-	if depl.IncidentHandling != nil {
-		definitions, err := this.camunda.GetRawDefinitionsByDeployment(deploymentId, depl.UserId)
-		if err != nil {
-			removeErr := this.camunda.RemoveProcess(deploymentId, depl.UserId)
-			if removeErr != nil {
-				this.config.GetLogger().Error("unable to remove deployed process", "deploymentId", deploymentId, "error", removeErr, "origErr", err)
-			}
-			return err, http.StatusInternalServerError
-		}
-		if len(definitions) == 0 {
-			this.config.GetLogger().Warn("no definitions for deployment found --> no incident handling deployed")
-		}
-		for _, definition := range definitions {
-			err, _ = client.New(this.config.IncidentApiUrl).SetOnIncidentHandler(client.InternalAdminToken, client.OnIncident{
-				ProcessDefinitionId: definition.Id,
-				Restart:             depl.IncidentHandling.Restart,
-				Notify:              depl.IncidentHandling.Notify,
-			})
-			if err != nil {
-				removeErr := this.camunda.RemoveProcess(deploymentId, depl.UserId)
-				if removeErr != nil {
-					this.config.GetLogger().Error("unable to remove deployed process", "deploymentId", deploymentId, "error", removeErr, "origErr", err)
-				}
-				return err, http.StatusInternalServerError
-			}
-		}
-	}
-	this.config.GetLogger().Debug("save vid relation", "vid", depl.Id, "deplId", deploymentId)
-	err = this.vid.SaveVidRelation(depl.Id, deploymentId)
+// DeleteDeployment removes all versions and the start schedules of the deployment
+func (this *Controller) DeleteDeployment(userId string, vid string) error {
+	err := this.deleteDeployment(userId, vid)
 	if err != nil {
-		this.config.GetLogger().Warn("unable to publish deployment saga --> remove deployed process", "error", err)
-		removeErr := this.camunda.RemoveProcess(deploymentId, depl.UserId)
-		if removeErr != nil {
-			this.config.GetLogger().Error("unable to remove deployed process", "deploymentId", deploymentId, "error", removeErr, "origErr", err)
+		return err
+	}
+	if this.schedules != nil {
+		err = this.schedules.RemoveByVid(vid)
+		if err != nil {
+			return err
 		}
-		return err, http.StatusInternalServerError
 	}
-	return err, http.StatusOK
+	this.publishChange(model.ChangeEvent{Type: model.ChangeEventDeploymentDeleted, UserId: userId, DeploymentId: vid})
+	return nil
 }
 
-func (this *Controller) DeleteDeployment(userId string, vid string) error {
-	id, exists, err := this.vid.GetDeploymentId(vid)
+// deleteDeployment removes all versions of the deployment.
+// a version is only removed from the database if its engine deployment was removed, so that failed versions
+// stay related to the vid and the deletion may be repeated. the active version is removed last, because the
+// access to the deployment is checked with it; the vid relation is removed after all versions are removed.
+func (this *Controller) deleteDeployment(userId string, vid string) error {
+	versions, err := this.vid.GetVersions(vid)
 	if err != nil {
 		return err
 	}
-	if !exists {
+	if len(versions) == 0 {
 		return nil
 	}
+	slices.SortStableFunc(versions, func(a, b model.DeploymentVersion) int {
+		if a.Active == b.Active {
+			return 0
+		}
+		if a.Active {
+			return 1
+		}
+		return -1
+	})
 
-	err = this.deleteIncidentsByDeploymentId(id, userId)
+	failures := []error{}
+	for _, version := range versions {
+		if version.Active && len(failures) > 0 {
+			break
+		}
+		err = this.removeVersion(userId, version.DeploymentId)
+		if err != nil {
+			this.config.GetLogger().Warn("unable to remove deployment version", "vid", vid, "version", version.Version, "deploymentId", version.DeploymentId, "error", err)
+			failures = append(failures, fmt.Errorf("unable to remove version %v: %w", version.Version, err))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.Join(failures...)
+	}
+
+	commit, _, err := this.vid.RemoveVidRelation(vid, versions[0].DeploymentId)
 	if err != nil {
 		return err
 	}
+	return commit()
+}
 
-	err = this.deleteIoVariablesByDeploymentId(id, userId)
+// removeVersion removes the engine deployment of a version with its incidents and io variables and, on success, its vid relation.
+// without userId the deployment is removed from all shards.
+func (this *Controller) removeVersion(userId string, deploymentId string) (err error) {
+	err = this.deleteIncidentsByDeploymentId(deploymentId, userId)
 	if err != nil {
 		return err
 	}
-
-	commit, rollback, err := this.vid.RemoveVidRelation(vid, id)
+	err = this.deleteIoVariablesByDeploymentId(deploymentId, userId)
 	if err != nil {
 		return err
 	}
 	if userId != "" {
-		err = this.camunda.RemoveProcess(id, userId)
+		err = this.camunda.RemoveProcess(deploymentId, userId)
 	} else {
-		err = this.camunda.RemoveProcessFromAllShards(id)
+		err = this.camunda.RemoveProcessFromAllShards(deploymentId)
 	}
 	if err != nil {
-		_ = rollback()
-	} else {
-		return commit()
+		return err
 	}
-	return err
+	return this.vid.RemoveVersion(deploymentId)
+}
+
+func (this *Controller) GetDeploymentVersions(vid string) ([]model.DeploymentVersion, error) {
+	return this.vid.GetVersions(vid)
+}
+
+// RollbackDeployment deploys a previous version again as new active version of the deployment.
+// activating the previous process-definition would leave message and timer start events on the newest definition,
+// so the bpmn of the version is redeployed instead. the stored incident handling is applied to the new version.
+// new process-instances are started with the new version; running process-instances are not changed.
+func (this *Controller) RollbackDeployment(userId string, vid string, version int) (err error, code int) {
+	versions, err := this.vid.GetVersions(vid)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	index := slices.IndexFunc(versions, func(v model.DeploymentVersion) bool {
+		return v.Version == version
+	})
+	if index < 0 {
+		return errors.New("unknown version"), http.StatusNotFound
+	}
+	if versions[index].Active {
+		return nil, http.StatusOK
+	}
+	definitions, err := this.camunda.GetRawDefinitionsByDeployment(versions[index].DeploymentId, userId)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	if len(definitions) == 0 {
+		return errors.New("version is no longer deployed"), http.StatusConflict
+	}
+	deployment, xml, svg, err := this.camunda.GetRawDeploymentWithFiles(versions[index].DeploymentId, userId)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	depl := model.DeploymentMessage{
+		Deployment: model.Deployment{
+			Id:   vid,
+			Name: deployment.Name,
+			Diagram: model.Diagram{
+				XmlDeployed: xml,
+				Svg:         svg,
+			},
+		},
+		UserId: userId,
+		Source: deployment.Source,
+	}
+	handling, exists, err := this.vid.GetIncidentHandling(vid)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	if exists {
+		depl.IncidentHandling = &handling
+	}
+	job, err, code := this.createIdleJob(depl, model.DeploymentJobStateRunning, "")
+	if err != nil {
+		return err, code
+	}
+	return this.runDeploymentJob(&job)
+}
+
+// removeExceedingVersions removes inactive versions of the deployment exceeding config.DeploymentVersionsKept
+func (this *Controller) removeExceedingVersions(userId string, vid string) error {
+	deploymentIds, err := this.vid.GetExceedingVersions(vid, this.config.DeploymentVersionsKept)
+	if err != nil {
+		return err
+	}
+	for _, id := range deploymentIds {
+		err = this.removeVersion(userId, id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (this *Controller) DeleteHistoricProcessInstance(userId string, instanceId string) (err error, code int) {
@@ -172,6 +341,22 @@ func (this *Controller) DeleteHistoricProcessInstance(userId string, instanceId
 	return nil, http.StatusOK
 }
 
+// RestartHistoricProcessInstance starts a new process-instance with the initial variables of a finished process-instance
+func (this *Controller) RestartHistoricProcessInstance(userId string, instanceId string, request model.RestartRequest) (err error, code int) {
+	_, err = this.camunda.CheckHistoryAccess(instanceId, userId)
+	if err != nil {
+		return errors.New("access denied"), http.StatusUnauthorized
+	}
+	instance, err := this.camunda.GetHistoricProcessInstance(instanceId, userId)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	if instance.State == "ACTIVE" || instance.State == "SUSPENDED" {
+		return errors.New("process-instance is still running"), http.StatusConflict
+	}
+	return this.camunda.RestartProcessInstance(instance, userId, request)
+}
+
 func validateXml(xmlStr string) bool {
 	if xmlStr == "" {
 		return false
@@ -195,7 +380,7 @@ func (this *Controller) cleanupExistingDeployment(userId string, vid string) err
 		return err
 	}
 	if exists {
-		return this.DeleteDeployment(userId, vid)
+		return this.deleteDeployment(userId, vid)
 	}
 	return nil
 }
@@ -0,0 +1,385 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/camunda"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+// DeployJob deploys the process and records every finished step in a deployment job.
+// deployments interrupted by a restart are resumed or compensated by StartDeploymentJobRecovery.
+// deployments of a vid with a queued or running job are rejected with 409.
+func (this *Controller) DeployJob(depl model.DeploymentMessage) (job model.DeploymentJob, err error, code int) {
+	depl, err, code = this.prepareDeployment(depl)
+	if err != nil {
+		return job, err, code
+	}
+	job, err, code = this.createIdleJob(depl, model.DeploymentJobStateRunning, "")
+	if err != nil {
+		return job, err, code
+	}
+	err, code = this.runDeploymentJob(&job)
+	return job, err, code
+}
+
+var ErrDeploymentJobActive = errors.New("a deployment of this id is already queued or running")
+
+// DeployAsync checks the deployment and queues it for the workers started by StartDeploymentWorkers.
+// the returned job may be polled with GetDeploymentJob; if callbackUrl is set, the finished job is posted to it.
+// deployments of a vid with a queued or running job are rejected with 409, so that workers never deploy the same vid concurrently.
+func (this *Controller) DeployAsync(depl model.DeploymentMessage, callbackUrl string) (job model.DeploymentJob, err error, code int) {
+	if this.jobs == nil || this.deploymentQueue == nil {
+		return job, errors.New("asynchronous deployments are not available"), http.StatusServiceUnavailable
+	}
+	if callbackUrl != "" {
+		parsed, err := url.Parse(callbackUrl)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return job, errors.New("invalid callback url"), http.StatusBadRequest
+		}
+	}
+	depl, err, code = this.prepareDeployment(depl)
+	if err != nil {
+		return job, err, code
+	}
+	job, err, code = this.createIdleJob(depl, model.DeploymentJobStateQueued, callbackUrl)
+	if err != nil {
+		return job, err, code
+	}
+	select {
+	case this.deploymentQueue <- job:
+		return job, nil, http.StatusAccepted
+	default:
+		err = errors.New("deployment queue is full")
+		this.failJob(&job, err)
+		return job, err, http.StatusServiceUnavailable
+	}
+}
+
+// StartDeploymentWorkers starts config.DeploymentWorkers workers for jobs queued by DeployAsync.
+// jobs still queued when ctx is done are resumed by StartDeploymentJobRecovery.
+func (this *Controller) StartDeploymentWorkers(ctx context.Context) error {
+	if this.jobs == nil || this.config.DeploymentWorkers <= 0 {
+		return nil
+	}
+	if this.config.DeploymentQueueSize < 0 {
+		return errors.New("invalid deployment_queue_size")
+	}
+	this.deploymentQueue = make(chan model.DeploymentJob, this.config.DeploymentQueueSize)
+	for i := int64(0); i < this.config.DeploymentWorkers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-this.deploymentQueue:
+					this.runQueuedDeploymentJob(job)
+				}
+			}
+		}()
+	}
+	return nil
+}
+
+func (this *Controller) runQueuedDeploymentJob(job model.DeploymentJob) {
+	logger := this.config.GetLogger()
+	claimed, err := this.jobs.ClaimQueued(&job)
+	if err != nil {
+		logger.Error("unable to claim queued deployment job", "id", job.Id, "error", err)
+		return
+	}
+	if !claimed {
+		logger.Debug("queued deployment job already claimed by recovery", "id", job.Id)
+		return
+	}
+	err, _ = this.runDeploymentJob(&job)
+	if err != nil {
+		logger.Warn("unable to deploy process", "job", job.Id, "vid", job.DeploymentId, "error", err)
+	}
+	this.sendJobCallback(job)
+}
+
+// sendJobCallback posts the finished job to its callback url
+func (this *Controller) sendJobCallback(job model.DeploymentJob) {
+	if job.CallbackUrl == "" {
+		return
+	}
+	body, err := json.Marshal(job)
+	if err != nil {
+		this.config.GetLogger().Error("unable to marshal deployment job for callback", "id", job.Id, "error", err)
+		return
+	}
+	resp, err := http.Post(job.CallbackUrl, "application/json", bytes.NewReader(body))
+	if err != nil {
+		this.config.GetLogger().Warn("unable to send deployment job callback", "id", job.Id, "url", job.CallbackUrl, "error", err)
+		return
+	}
+	defer resp.Body.Close()
+	_, _ = io.ReadAll(resp.Body)
+	if resp.StatusCode >= 300 {
+		this.config.GetLogger().Warn("unexpected response to deployment job callback", "id", job.Id, "url", job.CallbackUrl, "status", resp.Status)
+	}
+}
+
+func (this *Controller) GetDeploymentJob(id string) (job model.DeploymentJob, err error, code int) {
+	if this.jobs == nil {
+		return job, errors.New("deployment jobs are not stored"), http.StatusNotFound
+	}
+	job, exists, err := this.jobs.Get(id)
+	if err != nil {
+		return job, err, http.StatusInternalServerError
+	}
+	if !exists {
+		return job, errors.New("unknown deployment job"), http.StatusNotFound
+	}
+	return job, nil, http.StatusOK
+}
+
+// StartDeploymentJobRecovery resumes running deployment jobs without progress since config.DeploymentJobStaleTimeout.
+// jobs that can not be resumed are compensated. checks on startup and every config.DeploymentJobStaleTimeout.
+func (this *Controller) StartDeploymentJobRecovery(ctx context.Context) error {
+	if this.jobs == nil {
+		return nil
+	}
+	staleTimeout, err := time.ParseDuration(this.config.DeploymentJobStaleTimeout)
+	if err != nil {
+		return fmt.Errorf("invalid deployment_job_stale_timeout: %w", err)
+	}
+	retention, err := time.ParseDuration(this.config.DeploymentJobRetention)
+	if err != nil {
+		return fmt.Errorf("invalid deployment_job_retention: %w", err)
+	}
+	go func() {
+		ticker := time.NewTicker(staleTimeout)
+		defer ticker.Stop()
+		for {
+			this.recoverDeploymentJobs(staleTimeout, retention)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+func (this *Controller) recoverDeploymentJobs(staleTimeout time.Duration, retention time.Duration) {
+	logger := this.config.GetLogger()
+	for {
+		job, exists, err := this.jobs.ClaimStale(staleTimeout)
+		if err != nil {
+			logger.Error("unable to load stale deployment jobs", "error", err)
+			return
+		}
+		if !exists {
+			break
+		}
+		logger.Info("resume deployment job", "id", job.Id, "vid", job.DeploymentId, "step", job.Step)
+		err, _ = this.runDeploymentJob(&job)
+		if err != nil {
+			logger.Warn("unable to resume deployment job", "id", job.Id, "vid", job.DeploymentId, "error", err)
+		}
+		this.sendJobCallback(job)
+	}
+	err := this.jobs.RemoveFinished(retention)
+	if err != nil {
+		logger.Error("unable to remove finished deployment jobs", "error", err)
+	}
+}
+
+func (this *Controller) createJob(depl model.DeploymentMessage, state string, callbackUrl string) (job model.DeploymentJob, err error) {
+	if this.jobs == nil {
+		now := time.Now()
+		return model.DeploymentJob{
+			DeploymentId: depl.Id,
+			UserId:       depl.UserId,
+			Step:         model.DeploymentJobStepCreated,
+			State:        state,
+			CallbackUrl:  callbackUrl,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+			Message:      depl,
+		}, nil
+	}
+	return this.jobs.Create(depl, state, callbackUrl)
+}
+
+// createIdleJob creates a job like createJob, but fails with ErrDeploymentJobActive if a job of the vid is queued or running,
+// so that deployments of the same vid never run concurrently
+func (this *Controller) createIdleJob(depl model.DeploymentMessage, state string, callbackUrl string) (job model.DeploymentJob, err error, code int) {
+	if this.jobs == nil {
+		job, err = this.createJob(depl, state, callbackUrl)
+		if err != nil {
+			return job, err, http.StatusInternalServerError
+		}
+		return job, nil, http.StatusOK
+	}
+	job, created, err := this.jobs.CreateIfIdle(depl, state, callbackUrl)
+	if err != nil {
+		return job, err, http.StatusInternalServerError
+	}
+	if !created {
+		return job, ErrDeploymentJobActive, http.StatusConflict
+	}
+	return job, nil, http.StatusOK
+}
+
+// keepJobAlive updates the job every third of config.DeploymentJobStaleTimeout until stop is called,
+// so that StartDeploymentJobRecovery of other wrapper instances does not claim a long-running job
+func (this *Controller) keepJobAlive(job *model.DeploymentJob) (stop func()) {
+	staleTimeout, err := time.ParseDuration(this.config.DeploymentJobStaleTimeout)
+	if this.jobs == nil || job.Id == "" || err != nil || staleTimeout <= 0 {
+		return func() {}
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(staleTimeout / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				err := this.jobs.Touch(job.Id)
+				if err != nil {
+					this.config.GetLogger().Warn("unable to update running deployment job", "id", job.Id, "error", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+	}
+}
+
+func (this *Controller) saveJob(job *model.DeploymentJob) error {
+	if this.jobs == nil {
+		return nil
+	}
+	return this.jobs.Update(job)
+}
+
+// runDeploymentJob executes the steps following job.Step.
+// the engine deployment id is recorded with the deployed step right after the deployment; only recorded deployments are compensated.
+// a job interrupted between the deployment and the record deploys the process again, the unrecorded deployment is left to the cleanup command (list-unlinked-pid).
+func (this *Controller) runDeploymentJob(job *model.DeploymentJob) (err error, code int) {
+	depl := job.Message
+	//the deployment is finished before a rebalance of the user starts to move the deployments
+	unlock, err := this.camunda.LockUserDeployments(depl.UserId)
+	if err != nil {
+		this.failJob(job, err)
+		if errors.Is(err, camunda.ErrUserMoving) {
+			return err, http.StatusServiceUnavailable
+		}
+		return err, http.StatusInternalServerError
+	}
+	defer unlock()
+	defer this.keepJobAlive(job)()
+	for job.Step != model.DeploymentJobStepDone {
+		next := ""
+		switch job.Step {
+		case model.DeploymentJobStepCreated:
+			if this.config.DeploymentVersionsKept <= 0 {
+				err = this.cleanupExistingDeployment(depl.UserId, depl.Id)
+			}
+			next = model.DeploymentJobStepCleaned
+		case model.DeploymentJobStepCleaned:
+			this.config.GetLogger().Debug("deploy process", "id", depl.Id, "name", depl.Name, "user", depl.UserId, "xml", depl.Diagram.XmlDeployed)
+			job.EngineDeploymentId, err = this.camunda.DeployProcess(depl.Name, depl.Diagram.XmlDeployed, depl.Diagram.Svg, depl.UserId, depl.Source)
+			if err != nil {
+				this.config.GetLogger().Warn("unable to deploy process to camunda ", "error", err)
+			}
+			next = model.DeploymentJobStepDeployed
+		case model.DeploymentJobStepDeployed:
+			err = this.setIncidentHandling(job.EngineDeploymentId, depl)
+			next = model.DeploymentJobStepIncidentHandling
+		case model.DeploymentJobStepIncidentHandling:
+			err = this.linkDeployment(job)
+			next = model.DeploymentJobStepLinked
+		case model.DeploymentJobStepLinked:
+			if this.config.DeploymentVersionsKept > 0 {
+				removeErr := this.removeExceedingVersions(depl.UserId, depl.Id)
+				if removeErr != nil {
+					this.config.GetLogger().Warn("unable to remove old deployment versions", "vid", depl.Id, "error", removeErr)
+				}
+			}
+			next = model.DeploymentJobStepDone
+		default:
+			err = fmt.Errorf("unknown deployment job step %v", job.Step)
+		}
+		if err != nil {
+			this.failJob(job, err)
+			return err, http.StatusInternalServerError
+		}
+		job.Step = next
+		if next == model.DeploymentJobStepDone {
+			job.State = model.DeploymentJobStateDone
+		}
+		err = this.saveJob(job)
+		if err != nil {
+			this.failJob(job, err)
+			return err, http.StatusInternalServerError
+		}
+	}
+	this.publishChange(model.ChangeEvent{Type: model.ChangeEventDeploymentCreated, UserId: depl.UserId, DeploymentId: depl.Id, DeploymentName: depl.Name})
+	return nil, http.StatusOK
+}
+
+func (this *Controller) linkDeployment(job *model.DeploymentJob) error {
+	_, exists, err := this.vid.GetVirtualId(job.EngineDeploymentId)
+	if err != nil {
+		return err
+	}
+	if exists {
+		//linked before the job was interrupted
+		return nil
+	}
+	this.config.GetLogger().Debug("save vid relation", "vid", job.DeploymentId, "deplId", job.EngineDeploymentId)
+	return this.vid.SaveVidRelation(job.DeploymentId, job.EngineDeploymentId)
+}
+
+// failJob marks the job as failed and removes the deployed process, if it is not linked to the vid
+func (this *Controller) failJob(job *model.DeploymentJob, cause error) {
+	logger := this.config.GetLogger()
+	job.State = model.DeploymentJobStateFailed
+	job.Error = cause.Error()
+	if job.EngineDeploymentId != "" {
+		_, linked, err := this.vid.GetVirtualId(job.EngineDeploymentId)
+		if err == nil && !linked {
+			err = this.camunda.RemoveProcess(job.EngineDeploymentId, job.UserId)
+		}
+		if err != nil {
+			logger.Error("unable to remove deployed process", "deploymentId", job.EngineDeploymentId, "error", err, "origErr", cause)
+			job.Error = job.Error + "; unable to remove deployed process: " + err.Error()
+		}
+	}
+	err := this.saveJob(job)
+	if err != nil {
+		logger.Error("unable to save failed deployment job", "id", job.Id, "error", err)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+// instanceEventPollOverlap is subtracted from the newest polled event time for the next poll,
+// so that events committed by the engine after a poll with an earlier timestamp are not lost
+const instanceEventPollOverlap = time.Minute
+
+// polledEvents remembers the events of the overlap window, so that overlapping polls report every event once
+type polledEvents struct {
+	start time.Time //events before the first poll are not reported
+	mark  time.Time
+	seen  map[string]time.Time
+}
+
+func newPolledEvents(now time.Time) *polledEvents {
+	return &polledEvents{start: now, mark: now, seen: map[string]time.Time{}}
+}
+
+func (this *polledEvents) since() time.Time {
+	return this.mark.Add(-instanceEventPollOverlap)
+}
+
+func (this *polledEvents) filterNew(events []model.ProcessInstanceEvent) (result []model.ProcessInstanceEvent) {
+	for _, event := range events {
+		key := event.Key()
+		if _, ok := this.seen[key]; ok {
+			continue
+		}
+		this.seen[key] = event.Time
+		if event.Time.After(this.mark) {
+			this.mark = event.Time
+		}
+		if event.Time.Before(this.start) {
+			continue
+		}
+		result = append(result, event)
+	}
+	for key, t := range this.seen {
+		if t.Before(this.since()) {
+			delete(this.seen, key)
+		}
+	}
+	return result
+}
+
+// StartInstanceEventPoller publishes finished process-instances and incidents found in the engine history every config.InstanceEventPollInterval.
+// only shards of tenants with event subscriptions are polled; all shards are polled if listeners for all tenants are registered.
+func (this *Controller) StartInstanceEventPoller(ctx context.Context) error {
+	interval, err := time.ParseDuration(this.config.InstanceEventPollInterval)
+	if err != nil {
+		return fmt.Errorf("invalid instance_event_poll_interval: %w", err)
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		polled := map[string]*polledEvents{}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			polled = this.pollInstanceEvents(polled)
+		}
+	}()
+	return nil
+}
+
+// pollInstanceEvents polls the shards of subscribed tenants; shards without subscribed tenants are dropped from polled,
+// so that a new subscription does not receive old events
+func (this *Controller) pollInstanceEvents(polled map[string]*polledEvents) map[string]*polledEvents {
+	all, tenants := this.camunda.Events().Tenants()
+	tenantsByShard := map[string][]string{}
+	var err error
+	if all {
+		shards, err := this.camunda.GetShards()
+		if err != nil {
+			this.config.GetLogger().Error("unable to get shards for event poll", "error", err)
+			return polled
+		}
+		for _, shard := range shards {
+			tenantsByShard[shard] = nil
+		}
+	} else if len(tenants) > 0 {
+		tenantsByShard, err = this.camunda.GetShardsOfTenants(tenants)
+		if err != nil {
+			this.config.GetLogger().Error("unable to get shards for event poll", "error", err)
+			return polled
+		}
+	}
+
+	result := map[string]*polledEvents{}
+	for shard, shardTenants := range tenantsByShard {
+		state, ok := polled[shard]
+		if !ok {
+			state = newPolledEvents(time.Now())
+		}
+		result[shard] = state
+		finished, err := this.camunda.GetFinishedInstanceEventsForShard(shard, shardTenants, state.since())
+		if err != nil {
+			this.config.GetLogger().Error("unable to poll finished process-instances", "error", err)
+			continue
+		}
+		incidents, err := this.camunda.GetIncidentEventsForShard(shard, shardTenants, state.since())
+		if err != nil {
+			this.config.GetLogger().Error("unable to poll incidents", "error", err)
+			continue
+		}
+		for _, event := range state.filterNew(append(finished, incidents...)) {
+			this.camunda.Events().Publish(event)
+		}
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+// IdempotencyPendingLease is the time a reserved idempotency key blocks retries while its start is running;
+// older pending keys are left by interrupted starts and may be reserved again
+var IdempotencyPendingLease = 5 * time.Minute
+
+// StartIdempotent runs start once per user and idempotency key within config.IdempotencyWindow.
+// retries with the same key and request get the stored response of the first successful start (replayed = true);
+// failed starts (status code >= 300) are not stored and may be retried with the same key.
+func (this *Controller) StartIdempotent(userId string, key string, requestHash string, start func() model.IdempotentResponse) (response model.IdempotentResponse, replayed bool, err error, code int) {
+	if this.idempotency == nil || key == "" {
+		return start(), false, nil, http.StatusOK
+	}
+	if len(key) > 255 {
+		return response, false, errors.New("idempotency key exceeds 255 characters"), http.StatusBadRequest
+	}
+	window, err := time.ParseDuration(this.config.IdempotencyWindow)
+	if err != nil {
+		return response, false, fmt.Errorf("invalid idempotency_window: %w", err), http.StatusInternalServerError
+	}
+	existing, reserved, err := this.idempotency.Reserve(userId, key, requestHash, window, IdempotencyPendingLease)
+	if err != nil {
+		return response, false, err, http.StatusInternalServerError
+	}
+	if !reserved {
+		if existing.RequestHash != requestHash {
+			return response, false, errors.New("idempotency key is already used for a different request"), http.StatusUnprocessableEntity
+		}
+		if existing.Response.Code == 0 {
+			return response, false, errors.New("request with this idempotency key is in progress"), http.StatusConflict
+		}
+		return existing.Response, true, nil, http.StatusOK
+	}
+
+	response = start()
+	if response.Code >= 300 {
+		err = this.idempotency.Release(userId, key)
+		if err != nil {
+			this.config.GetLogger().Error("unable to release idempotency key", "user", userId, "key", key, "error", err)
+		}
+		return response, false, nil, http.StatusOK
+	}
+	instance := model.ProcessInstance{}
+	_ = json.Unmarshal(response.Body, &instance) //not every start endpoint responds with the process-instance
+	err = this.idempotency.Complete(userId, key, instance.Id, response)
+	if err != nil {
+		//the process is started, so the response is still returned
+		this.config.GetLogger().Error("unable to store idempotency key", "user", userId, "key", key, "error", err)
+	}
+	return response, false, nil, http.StatusOK
+}
+
+// StartIdempotencyKeyCleanup removes expired idempotency keys hourly
+func (this *Controller) StartIdempotencyKeyCleanup(ctx context.Context) error {
+	if this.idempotency == nil {
+		return nil
+	}
+	window, err := time.ParseDuration(this.config.IdempotencyWindow)
+	if err != nil {
+		return fmt.Errorf("invalid idempotency_window: %w", err)
+	}
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			err := this.idempotency.RemoveExpired(window)
+			if err != nil {
+				this.config.GetLogger().Error("unable to remove expired idempotency keys", "error", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+const (
+	ImplementationCheckOff    = "off"
+	ImplementationCheckStrip  = "strip"
+	ImplementationCheckReject = "reject"
+)
+
+// ValidateImplementationCheck returns an error for unknown config.ImplementationCheck values
+func ValidateImplementationCheck(check string) error {
+	switch check {
+	case "", ImplementationCheckOff, ImplementationCheckStrip, ImplementationCheckReject:
+		return nil
+	default:
+		return fmt.Errorf("unknown implementation_check %q", check)
+	}
+}
+
+// checkImplementations applies config.ImplementationCheck and the configured allow-list to the xml (see CheckImplementations)
+func (this *Controller) checkImplementations(xml string) (result string, stripped []model.ValidationMessage, rejected []model.ValidationMessage, err error) {
+	switch this.config.ImplementationCheck {
+	case "", ImplementationCheckOff:
+		return xml, nil, nil, nil
+	case ImplementationCheckStrip, ImplementationCheckReject:
+		return CheckImplementations(xml, ImplementationAllowList{
+			Classes:             this.config.AllowedDelegateClasses,
+			DelegateExpressions: this.config.AllowedDelegateExpressions,
+			Expressions:         this.config.AllowedExpressions,
+			Connectors:          this.config.AllowedConnectors,
+		}, this.config.ImplementationCheck == ImplementationCheckStrip)
+	default:
+		return xml, nil, nil, fmt.Errorf("unknown implementation check %q", this.config.ImplementationCheck)
+	}
+}
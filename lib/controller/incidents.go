@@ -0,0 +1,133 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/camunda"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/process-incident-api/lib/client"
+)
+
+var errIncidentHandlingUnknown = errors.New("no incident handling set for deployment")
+
+// GetDeploymentIncidentHandling returns the incident handling last set for the deployment by a deployment or SetDeploymentIncidentHandling.
+// deployments without stored incident handling (deployed before it was stored) use the handler registered at the process-incident-api,
+// which is stored for later requests.
+func (this *Controller) GetDeploymentIncidentHandling(userId string, vid string) (result model.IncidentHandling, err error, code int) {
+	definitions, err := this.camunda.GetDefinitionByDeploymentVid(vid, userId)
+	if errors.Is(err, camunda.UnknownVid) {
+		return result, err, http.StatusNotFound
+	}
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	result, exists, err := this.getIncidentHandling(vid, definitions)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !exists {
+		return result, errIncidentHandlingUnknown, http.StatusNotFound
+	}
+	return result, nil, http.StatusOK
+}
+
+// SetDeploymentIncidentHandling registers the incident handling for the process-definitions of the active deployment version.
+// handlers apply per process-definition, so incidents of running process-instances of the active version are handled with the new settings.
+func (this *Controller) SetDeploymentIncidentHandling(userId string, vid string, handling model.IncidentHandling) (err error, code int) {
+	definitions, err := this.camunda.GetDefinitionByDeploymentVid(vid, userId)
+	if errors.Is(err, camunda.UnknownVid) {
+		return err, http.StatusNotFound
+	}
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	err = this.registerIncidentHandling(definitions, handling)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	err = this.vid.SetIncidentHandling(vid, handling)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
+
+// getIncidentHandling returns the stored incident handling of the vid;
+// if none is stored, the handler of the process-incident-api for the given process-definitions is used and stored (backfill)
+func (this *Controller) getIncidentHandling(vid string, definitions model.ProcessDefinitions) (result model.IncidentHandling, exists bool, err error) {
+	result, exists, err = this.vid.GetIncidentHandling(vid)
+	if err != nil || exists {
+		return result, exists, err
+	}
+	for _, definition := range definitions {
+		result, exists, err = this.getRegisteredIncidentHandling(definition.Id)
+		if err != nil {
+			return result, false, err
+		}
+		if exists {
+			return result, true, this.vid.SetIncidentHandling(vid, result)
+		}
+	}
+	return result, false, nil
+}
+
+// getRegisteredIncidentHandling reads the on-incident handler of the process-definition from the process-incident-api
+func (this *Controller) getRegisteredIncidentHandling(definitionId string) (result model.IncidentHandling, exists bool, err error) {
+	req, err := http.NewRequest(http.MethodGet, this.config.IncidentApiUrl+"/on-incident-handler/"+url.PathEscape(definitionId), nil)
+	if err != nil {
+		return result, false, err
+	}
+	req.Header.Set("Authorization", client.InternalAdminToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return result, false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return result, false, nil
+	}
+	if resp.StatusCode >= 300 {
+		temp, _ := io.ReadAll(resp.Body)
+		return result, false, errors.New(resp.Status + " " + string(temp))
+	}
+	handler := client.OnIncident{}
+	err = json.NewDecoder(resp.Body).Decode(&handler)
+	if err != nil {
+		return result, false, err
+	}
+	return model.IncidentHandling{Restart: handler.Restart, Notify: handler.Notify}, true, nil
+}
+
+func (this *Controller) registerIncidentHandling(definitions model.ProcessDefinitions, handling model.IncidentHandling) error {
+	for _, definition := range definitions {
+		err, _ := client.New(this.config.IncidentApiUrl).SetOnIncidentHandler(client.InternalAdminToken, client.OnIncident{
+			ProcessDefinitionId: definition.Id,
+			Restart:             handling.Restart,
+			Notify:              handling.Notify,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/camunda"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/shards"
+	"github.com/SENERGY-Platform/process-incident-api/lib/client"
+)
+
+var ErrInstancesNotDrained = errors.New("source shard still has running process-instances")
+
+var ErrRebalanceRunning = errors.New("rebalance of user is already running")
+
+var RebalanceDrainInterval = 5 * time.Second
+
+// RebalanceMoveLease limits the time process starts of a user are blocked by a rebalance (in addition to the drain timeout),
+// so that starts are not blocked forever if the rebalance is interrupted
+var RebalanceMoveLease = 10 * time.Minute
+
+// RebalanceCacheDelay is the time process starts stay blocked after the shard assignment is switched,
+// while other wrapper instances may still use their cached shard assignment
+var RebalanceCacheDelay = time.Minute
+
+// Rebalance moves all deployments of a user to another shard and assigns the user to this shard.
+// the deployments are redeployed from the bpmn and svg stored in the source shard,
+// vid relations and the shard mapping are switched together and the source deployments are removed afterward.
+// process starts and deployments of the user are blocked while the deployments are moved; a second rebalance of the user is rejected.
+// the stored incident handling of the deployments is registered for the redeployed process-definitions.
+// history of the source shard is not moved.
+func (this *Controller) Rebalance(request model.RebalanceRequest) (result model.RebalanceResult, err error, code int) {
+	userId := request.UserId
+	if userId == "" {
+		return result, errors.New("no user id provided"), http.StatusBadRequest
+	}
+	instanceHandling := request.InstanceHandling
+	if instanceHandling == "" {
+		instanceHandling = model.InstanceHandlingDrain
+	}
+	if instanceHandling != model.InstanceHandlingDrain && instanceHandling != model.InstanceHandlingCancel {
+		return result, errors.New("unknown instance_handling " + instanceHandling), http.StatusBadRequest
+	}
+	var drainTimeout time.Duration
+	if request.DrainTimeout != "" {
+		drainTimeout, err = time.ParseDuration(request.DrainTimeout)
+		if err != nil {
+			return result, err, http.StatusBadRequest
+		}
+	}
+
+	source, err := this.camunda.GetShardForUser(userId)
+	if errors.Is(err, shards.ErrorNotFound) {
+		return result, err, http.StatusNotFound
+	}
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	target := request.TargetShard
+	if target == "" {
+		target, err = this.camunda.SelectShardExcluding(source)
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+	} else {
+		knownShards, err := this.camunda.GetShards()
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+		if !slices.Contains(knownShards, target) {
+			return result, errors.New("unknown target shard"), http.StatusBadRequest
+		}
+	}
+	if target == source {
+		return result, errors.New("user is already assigned to target shard"), http.StatusBadRequest
+	}
+	result = model.RebalanceResult{
+		UserId:           userId,
+		SourceShard:      camunda.AnonymizeShard(source),
+		TargetShard:      camunda.AnonymizeShard(target),
+		MovedDeployments: []string{},
+	}
+	unlockRebalance, locked, err := this.camunda.TryLockUserRebalance(userId)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !locked {
+		return result, ErrRebalanceRunning, http.StatusConflict
+	}
+	defer unlockRebalance()
+
+	logger := this.config.GetLogger().With("user", userId, "source", result.SourceShard, "target", result.TargetShard)
+	logger.Info("start rebalance", "instanceHandling", instanceHandling)
+
+	err = this.camunda.SetUserMoving(userId, time.Now().Add(drainTimeout+RebalanceMoveLease))
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	switched := false
+	defer func() {
+		var unblockErr error
+		if switched {
+			unblockErr = this.camunda.SetUserMoving(userId, time.Now().Add(RebalanceCacheDelay))
+		} else {
+			unblockErr = this.camunda.UnsetUserMoving(userId)
+		}
+		if unblockErr != nil {
+			logger.Error("unable to update blocked process starts of user", "error", unblockErr)
+		}
+	}()
+
+	//new deployments are rejected from now on; running deployments are finished before the deployments are listed
+	waitCtx, cancel := context.WithTimeout(context.Background(), RebalanceMoveLease)
+	defer cancel()
+	unlockDeployments, err := this.camunda.WaitForUserDeployments(waitCtx, userId)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	defer unlockDeployments()
+
+	switch instanceHandling {
+	case model.InstanceHandlingCancel:
+		result.CancelledInstances, err = this.camunda.RemoveProcessInstancesForShard(source, userId)
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+	case model.InstanceHandlingDrain:
+		err = this.drainShard(source, userId, drainTimeout)
+		if errors.Is(err, ErrInstancesNotDrained) {
+			return result, err, http.StatusConflict
+		}
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+	}
+
+	deployments, err := this.camunda.GetRawDeploymentListForShard(source, userId)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+
+	//source deployment id -> target deployment id
+	replacements := map[string]string{}
+	sourceDefinitions := map[string]model.ProcessDefinitions{}
+	//source deployment id -> vid
+	vids := map[string]string{}
+	removeTargetDeployments := func() {
+		for _, deploymentId := range replacements {
+			removeErr := this.camunda.RemoveProcessForShard(deploymentId, target)
+			if removeErr != nil {
+				logger.Error("unable to remove deployed process from target shard", "deploymentId", deploymentId, "error", removeErr)
+			}
+		}
+	}
+	for _, deployment := range deployments {
+		vid, exists, err := this.vid.GetVirtualId(deployment.Id)
+		if err != nil {
+			removeTargetDeployments()
+			return result, err, http.StatusInternalServerError
+		}
+		if !exists {
+			logger.Warn("ignore deployment without vid", "deploymentId", deployment.Id)
+			continue
+		}
+		xml, svg, err := this.camunda.GetDeploymentFilesForShard(source, deployment.Id)
+		if err != nil {
+			removeTargetDeployments()
+			return result, err, http.StatusInternalServerError
+		}
+		definitions, err := this.camunda.GetRawDefinitionsByDeploymentForShard(source, deployment.Id)
+		if err != nil {
+			removeTargetDeployments()
+			return result, err, http.StatusInternalServerError
+		}
+		deploymentId, err := this.camunda.DeployProcessToShard(target, deployment.Name, xml, svg, userId, deployment.Source)
+		if err != nil {
+			removeTargetDeployments()
+			return result, err, http.StatusInternalServerError
+		}
+		logger.Debug("redeployed process", "vid", vid, "sourceDeploymentId", deployment.Id, "targetDeploymentId", deploymentId)
+		replacements[deployment.Id] = deploymentId
+		sourceDefinitions[deployment.Id] = definitions
+		vids[deployment.Id] = vid
+		result.MovedDeployments = append(result.MovedDeployments, vid)
+	}
+
+	commit, rollback, err := this.vid.ReplaceDeploymentIds(replacements)
+	if err != nil {
+		removeTargetDeployments()
+		return result, err, http.StatusInternalServerError
+	}
+	err = this.camunda.SetShardForUser(userId, target)
+	if err != nil {
+		_ = rollback()
+		removeTargetDeployments()
+		return result, err, http.StatusInternalServerError
+	}
+	err = commit()
+	if err != nil {
+		revertErr := this.camunda.SetShardForUser(userId, source)
+		if revertErr != nil {
+			logger.Error("unable to revert shard assignment", "error", revertErr, "origErr", err)
+		}
+		removeTargetDeployments()
+		return result, err, http.StatusInternalServerError
+	}
+	switched = true
+
+	for sourceDeploymentId, deploymentId := range replacements {
+		err = this.moveIncidentHandling(vids[sourceDeploymentId], sourceDefinitions[sourceDeploymentId], deploymentId, target)
+		if err != nil {
+			result.CleanupErrors = append(result.CleanupErrors, fmt.Sprintf("unable to register incident handling of %v: %v", vids[sourceDeploymentId], err.Error()))
+		}
+	}
+
+	//the user is now assigned to the target shard; failures while removing the source deployments
+	//are only reported, leftover deployments may be found with the cleanup command (list-unlinked-pid)
+	for deploymentId, definitions := range sourceDefinitions {
+		for _, definition := range definitions {
+			err, _ = client.New(this.config.IncidentApiUrl).DeleteIncidentByProcessDefinitionId(client.InternalAdminToken, definition.Id)
+			if err != nil {
+				result.CleanupErrors = append(result.CleanupErrors, err.Error())
+			}
+			if this.processIo != nil {
+				err = this.processIo.DeleteProcessDefinition(definition.Id)
+				if err != nil {
+					result.CleanupErrors = append(result.CleanupErrors, err.Error())
+				}
+			}
+		}
+		err = this.camunda.RemoveProcessForShard(deploymentId, source)
+		if err != nil {
+			result.CleanupErrors = append(result.CleanupErrors, fmt.Sprintf("unable to remove %v from source shard: %v", deploymentId, err.Error()))
+		}
+	}
+	if len(result.CleanupErrors) > 0 {
+		logger.Warn("errors while removing source deployments", "errors", result.CleanupErrors)
+	}
+	logger.Info("finished rebalance", "deployments", len(result.MovedDeployments))
+	return result, nil, http.StatusOK
+}
+
+// moveIncidentHandling registers the incident handling of the vid (see getIncidentHandling) for the process-definitions of the deployment in the target shard
+func (this *Controller) moveIncidentHandling(vid string, sourceDefinitions model.ProcessDefinitions, deploymentId string, target string) error {
+	handling, exists, err := this.getIncidentHandling(vid, sourceDefinitions)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	definitions, err := this.camunda.GetRawDefinitionsByDeploymentForShard(target, deploymentId)
+	if err != nil {
+		return err
+	}
+	return this.registerIncidentHandling(definitions, handling)
+}
+
+func (this *Controller) drainShard(shard string, userId string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		count, err := this.camunda.GetProcessInstanceCountForShard(shard, userId)
+		if err != nil {
+			return err
+		}
+		if count.Count == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: %v running", ErrInstancesNotDrained, count.Count)
+		}
+		time.Sleep(RebalanceDrainInterval)
+	}
+}
@@ -0,0 +1,282 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/camunda"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/schedules"
+)
+
+var errSchedulesUnavailable = errors.New("start schedules are not available")
+
+// BusinessKeyTemplateData is the input of StartSchedule.BusinessKey templates
+type BusinessKeyTemplateData struct {
+	ScheduleId   string
+	DeploymentId string
+	Time         time.Time //planned run in the time zone of the schedule
+}
+
+func (this *Controller) ListSchedules(vid string) (result []model.StartSchedule, err error, code int) {
+	if this.schedules == nil {
+		return result, errSchedulesUnavailable, http.StatusNotImplemented
+	}
+	result, err = this.schedules.List(vid)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+// CreateSchedule stores a schedule for the deployment vid; access to the deployment has to be checked by the caller
+func (this *Controller) CreateSchedule(userId string, vid string, schedule model.StartSchedule) (result model.StartSchedule, err error, code int) {
+	if this.schedules == nil {
+		return result, errSchedulesUnavailable, http.StatusNotImplemented
+	}
+	schedule.UserId = userId
+	schedule.DeploymentId = vid
+	schedule, err = prepareSchedule(schedule, time.Now())
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	result, err = this.schedules.Create(schedule)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) GetSchedule(userId string, id string) (result model.StartSchedule, err error, code int) {
+	if this.schedules == nil {
+		return result, errSchedulesUnavailable, http.StatusNotImplemented
+	}
+	result, exists, err := this.schedules.Get(id)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !exists {
+		return result, errors.New("unknown schedule"), http.StatusNotFound
+	}
+	if result.UserId != userId {
+		return model.StartSchedule{}, errors.New("access denied"), http.StatusUnauthorized
+	}
+	return result, nil, http.StatusOK
+}
+
+// UpdateSchedule replaces cron, time zone, inputs, business key and enabled of the schedule; the next run is recalculated
+func (this *Controller) UpdateSchedule(userId string, id string, schedule model.StartSchedule) (result model.StartSchedule, err error, code int) {
+	existing, err, code := this.GetSchedule(userId, id)
+	if err != nil {
+		return result, err, code
+	}
+	schedule.Id = existing.Id
+	schedule.UserId = existing.UserId
+	schedule.DeploymentId = existing.DeploymentId
+	schedule, err = prepareSchedule(schedule, time.Now())
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	result, exists, err := this.schedules.Update(schedule)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !exists {
+		return result, errors.New("unknown schedule"), http.StatusNotFound
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) DeleteSchedule(userId string, id string) (err error, code int) {
+	_, err, code = this.GetSchedule(userId, id)
+	if err != nil {
+		return err, code
+	}
+	err = this.schedules.Remove(id)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
+
+// prepareSchedule validates the schedule and sets the default time zone and the next run after now
+func prepareSchedule(schedule model.StartSchedule, now time.Time) (result model.StartSchedule, err error) {
+	if schedule.TimeZone == "" {
+		schedule.TimeZone = "UTC"
+	}
+	location, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return result, fmt.Errorf("invalid time_zone: %w", err)
+	}
+	expr, err := schedules.ParseCronExpression(schedule.Cron)
+	if err != nil {
+		return result, fmt.Errorf("invalid cron: %w", err)
+	}
+	_, err = renderBusinessKey(schedule, now.In(location))
+	if err != nil {
+		return result, fmt.Errorf("invalid business_key: %w", err)
+	}
+	schedule.NextRun = nil
+	if schedule.Enabled {
+		next, err := expr.Next(now.In(location))
+		if err != nil {
+			return result, fmt.Errorf("invalid cron: %w", err)
+		}
+		schedule.NextRun = &next
+	}
+	return schedule, nil
+}
+
+func renderBusinessKey(schedule model.StartSchedule, runTime time.Time) (string, error) {
+	if !strings.Contains(schedule.BusinessKey, "{{") {
+		return schedule.BusinessKey, nil
+	}
+	tmpl, err := template.New("business_key").Option("missingkey=error").Parse(schedule.BusinessKey)
+	if err != nil {
+		return "", err
+	}
+	buf := bytes.Buffer{}
+	err = tmpl.Execute(&buf, BusinessKeyTemplateData{
+		ScheduleId:   schedule.Id,
+		DeploymentId: schedule.DeploymentId,
+		Time:         runTime,
+	})
+	return buf.String(), err
+}
+
+// StartScheduler checks for due start schedules every config.ScheduleCheckInterval.
+// only the wrapper instance holding the leader lock of the wrapper_db starts processes.
+func (this *Controller) StartScheduler(ctx context.Context) error {
+	if this.schedules == nil {
+		return nil
+	}
+	interval, err := time.ParseDuration(this.config.ScheduleCheckInterval)
+	if err != nil {
+		return fmt.Errorf("invalid schedule_check_interval: %w", err)
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		defer this.schedules.ResignLeader()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			leader, err := this.schedules.IsLeader(ctx)
+			if err != nil {
+				this.config.GetLogger().Error("unable to check scheduler leadership", "error", err)
+				continue
+			}
+			if leader {
+				this.runDueSchedules(time.Now())
+			}
+		}
+	}()
+	return nil
+}
+
+// runDueSchedules starts every due schedule once; runs missed while no scheduler was active are skipped
+func (this *Controller) runDueSchedules(now time.Time) {
+	due, err := this.schedules.ListDue(now)
+	if err != nil {
+		this.config.GetLogger().Error("unable to list due schedules", "error", err)
+		return
+	}
+	for _, schedule := range due {
+		planned := *schedule.NextRun
+		location, err := time.LoadLocation(schedule.TimeZone)
+		if err != nil {
+			location = time.UTC
+		}
+		var next *time.Time
+		expr, err := schedules.ParseCronExpression(schedule.Cron)
+		if err == nil {
+			var t time.Time
+			t, err = expr.Next(now.In(location))
+			if err == nil {
+				next = &t
+			}
+		}
+		if err != nil {
+			this.config.GetLogger().Error("unable to calculate next schedule run", "schedule", schedule.Id, "error", err)
+		}
+		claimed, err := this.schedules.ClaimRun(schedule.Id, planned, next)
+		if err != nil {
+			this.config.GetLogger().Error("unable to claim schedule run", "schedule", schedule.Id, "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		this.runSchedule(schedule, planned.In(location))
+	}
+}
+
+func (this *Controller) runSchedule(schedule model.StartSchedule, planned time.Time) {
+	instanceId, err := this.startScheduledProcess(schedule, planned)
+	status := model.StartScheduleStatusOk
+	errMsg := ""
+	if err != nil {
+		this.config.GetLogger().Warn("unable to start scheduled process", "schedule", schedule.Id, "deploymentId", schedule.DeploymentId, "error", err)
+		status = model.StartScheduleStatusFailed
+		errMsg = err.Error()
+	}
+	err = this.schedules.SetRunResult(schedule.Id, time.Now(), status, errMsg, instanceId)
+	if err != nil {
+		this.config.GetLogger().Error("unable to store schedule run result", "schedule", schedule.Id, "error", err)
+	}
+}
+
+func (this *Controller) startScheduledProcess(schedule model.StartSchedule, planned time.Time) (instanceId string, err error) {
+	definitions, err := this.camunda.GetDefinitionByDeploymentVid(schedule.DeploymentId, schedule.UserId)
+	if err != nil {
+		return "", err
+	}
+	if len(definitions) == 0 {
+		return "", errors.New("no definition for deployment found")
+	}
+	schema, err := this.camunda.GetProcessParameterSchema(definitions[0].Id, schedule.UserId)
+	if err != nil {
+		return "", err
+	}
+	if fieldErrs := camunda.ValidateStartParameter(schema, schedule.Inputs); len(fieldErrs) > 0 {
+		msgs := []string{}
+		for _, fieldErr := range fieldErrs {
+			msgs = append(msgs, fieldErr.Field+": "+fieldErr.Message)
+		}
+		return "", errors.New("invalid inputs: " + strings.Join(msgs, "; "))
+	}
+	businessKey, err := renderBusinessKey(schedule, planned)
+	if err != nil {
+		return "", err
+	}
+	instance, err := this.camunda.StartProcessGetId(definitions[0].Id, businessKey, schedule.UserId, schedule.Inputs)
+	if err != nil {
+		return "", err
+	}
+	return instance.Id, nil
+}
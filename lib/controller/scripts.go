@@ -0,0 +1,194 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+// Script is a script found in a bpmn process
+type Script struct {
+	Format   string //scriptFormat or language attribute
+	Source   string //inline script
+	Resource string //external script referenced by camunda:resource or resource
+}
+
+// ScriptPolicy decides if a script may be deployed; the returned error is used as rejection reason
+type ScriptPolicy interface {
+	CheckScript(script Script) error
+}
+
+const (
+	UnrestrictedScriptPolicyName = "unrestricted"
+	RestrictedScriptPolicyName   = "restricted"
+	NoScriptsPolicyName          = "no_scripts"
+)
+
+// UnrestrictedScriptPolicy allows every script; scripts are still prefixed by SecureProcessScripts
+type UnrestrictedScriptPolicy struct{}
+
+func (this UnrestrictedScriptPolicy) CheckScript(Script) error {
+	return nil
+}
+
+// NoScriptsPolicy rejects every script
+type NoScriptsPolicy struct{}
+
+func (this NoScriptsPolicy) CheckScript(Script) error {
+	return errors.New("scripts are not allowed")
+}
+
+// RestrictedScriptPolicy allows inline scripts with an allowed format, not containing a forbidden token
+type RestrictedScriptPolicy struct {
+	AllowedFormats  []string
+	ForbiddenTokens []string
+}
+
+func (this RestrictedScriptPolicy) CheckScript(script Script) error {
+	if script.Resource != "" {
+		return fmt.Errorf("external script resource %q is not allowed", script.Resource)
+	}
+	allowed := false
+	for _, format := range this.AllowedFormats {
+		if strings.EqualFold(format, script.Format) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("script format %q is not allowed", script.Format)
+	}
+	for _, token := range this.ForbiddenTokens {
+		if token != "" && strings.Contains(script.Source, token) {
+			return fmt.Errorf("script contains forbidden token %q", token)
+		}
+	}
+	return nil
+}
+
+func defaultScriptPolicies(config configuration.Config) map[string]ScriptPolicy {
+	return map[string]ScriptPolicy{
+		UnrestrictedScriptPolicyName: UnrestrictedScriptPolicy{},
+		NoScriptsPolicyName:          NoScriptsPolicy{},
+		RestrictedScriptPolicyName: RestrictedScriptPolicy{
+			AllowedFormats:  config.ScriptAllowedFormats,
+			ForbiddenTokens: config.ScriptForbiddenTokens,
+		},
+	}
+}
+
+// SetScriptPolicy registers a policy by name; the name may be used in config.ScriptPolicy and config.TenantScriptPolicies.
+// registering a builtin name (unrestricted, restricted, no_scripts) replaces the builtin policy.
+func (this *Controller) SetScriptPolicy(name string, policy ScriptPolicy) {
+	this.scriptPoliciesMux.Lock()
+	defer this.scriptPoliciesMux.Unlock()
+	this.scriptPolicies[name] = policy
+}
+
+// getScriptPolicy returns the policy configured for the tenant, the default policy (config.ScriptPolicy) or UnrestrictedScriptPolicy
+func (this *Controller) getScriptPolicy(userId string) (policy ScriptPolicy, err error) {
+	name, ok := this.config.TenantScriptPolicies[userId]
+	if !ok {
+		name = this.config.ScriptPolicy
+	}
+	if name == "" {
+		name = UnrestrictedScriptPolicyName
+	}
+	this.scriptPoliciesMux.RLock()
+	defer this.scriptPoliciesMux.RUnlock()
+	policy, ok = this.scriptPolicies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown script policy %q", name)
+	}
+	return policy, nil
+}
+
+// checkScripts applies the script policy of the tenant to all scripts of the bpmn
+func (this *Controller) checkScripts(userId string, root *bpmnElement) (errs []model.ValidationMessage) {
+	policy, err := this.getScriptPolicy(userId)
+	if err != nil {
+		this.config.GetLogger().Error("unable to check scripts", "error", err)
+		return []model.ValidationMessage{{Message: err.Error()}}
+	}
+	root.walk(func(element *bpmnElement) {
+		script, isScript := getScript(element)
+		if !isScript {
+			return
+		}
+		err := policy.CheckScript(script)
+		if err != nil {
+			errs = append(errs, element.message(err.Error()))
+		}
+	})
+	return errs
+}
+
+// getScript returns the script defined by a bpmn:scriptTask, a camunda:script or a bpmn:conditionExpression with a script language
+func getScript(element *bpmnElement) (script Script, isScript bool) {
+	switch {
+	case element.is(bpmnNamespace, "scriptTask"):
+		script = Script{
+			Format:   element.attr("", "scriptFormat"),
+			Resource: element.attr(camundaNamespace, "resource"),
+		}
+		for _, child := range element.Children {
+			if child.is(bpmnNamespace, "script") {
+				script.Source = child.Text
+			}
+		}
+		return script, true
+	case element.is(camundaNamespace, "script"):
+		return Script{
+			Format:   element.attr("", "scriptFormat"),
+			Source:   element.Text,
+			Resource: element.attr("", "resource"),
+		}, true
+	case element.is(bpmnNamespace, "conditionExpression") && element.attr("", "language") != "":
+		return Script{
+			Format:   element.attr("", "language"),
+			Source:   element.Text,
+			Resource: element.attr(camundaNamespace, "resource"),
+		}, true
+	}
+	return script, false
+}
+
+// scriptPolicyError combines the messages of checkScripts to a rejection reason
+func scriptPolicyError(errs []model.ValidationMessage) error {
+	return violationError("script policy violation", errs)
+}
+
+// violationError combines validation messages to a rejection reason
+func violationError(violation string, errs []model.ValidationMessage) error {
+	reasons := []string{}
+	for _, msg := range errs {
+		reason := msg.Message
+		if msg.ElementId != "" {
+			reason = msg.ElementId + ": " + reason
+		}
+		if msg.Line > 0 {
+			reason = fmt.Sprintf("line %v: %v", msg.Line, reason)
+		}
+		reasons = append(reasons, reason)
+	}
+	return errors.New(violation + ": " + strings.Join(reasons, "; "))
+}
@@ -0,0 +1,266 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+const bpmnNamespace = "http://www.omg.org/spec/BPMN/20100524/MODEL"
+const camundaNamespace = "http://camunda.org/schema/1.0/bpmn"
+
+var knownCamundaElements = map[string]bool{
+	"connector":               true,
+	"connectorId":             true,
+	"constraint":              true,
+	"entry":                   true,
+	"errorEventDefinition":    true,
+	"executionListener":       true,
+	"expression":              true,
+	"failedJobRetryTimeCycle": true,
+	"field":                   true,
+	"formData":                true,
+	"formField":               true,
+	"formProperty":            true,
+	"in":                      true,
+	"inputOutput":             true,
+	"inputParameter":          true,
+	"list":                    true,
+	"map":                     true,
+	"out":                     true,
+	"outputParameter":         true,
+	"potentialStarter":        true,
+	"properties":              true,
+	"property":                true,
+	"script":                  true,
+	"string":                  true,
+	"taskListener":            true,
+	"validation":              true,
+	"value":                   true,
+}
+
+// ValidateDeployment runs the checks of Deploy and a structural bpmn check without deploying the process.
+// positions of messages reference the received xml.
+func (this *Controller) ValidateDeployment(userId string, depl model.Deployment) (result model.DeploymentValidationResult) {
+	result = model.DeploymentValidationResult{
+		Errors:   []model.ValidationMessage{},
+		Warnings: []model.ValidationMessage{},
+	}
+	defer func() {
+		result.Valid = len(result.Errors) == 0
+	}()
+	if depl.Id == "" {
+		result.Errors = append(result.Errors, model.ValidationMessage{Message: "no deployment id provided"})
+	}
+	if depl.Diagram.Svg == "" {
+		result.Errors = append(result.Errors, model.ValidationMessage{Message: "no svg provided"})
+	}
+	if depl.Diagram.XmlDeployed == "" {
+		result.Errors = append(result.Errors, model.ValidationMessage{Message: "no xml provided"})
+		return result
+	}
+
+	root, err := parseBpmnElements(depl.Diagram.XmlDeployed)
+	if err != nil {
+		msg := model.ValidationMessage{Message: err.Error()}
+		var syntaxErr *xml.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			msg = model.ValidationMessage{Message: syntaxErr.Msg, Line: syntaxErr.Line}
+		}
+		result.Errors = append(result.Errors, msg)
+		return result
+	}
+
+	secured, err := SecureProcessScripts(depl.Diagram.XmlDeployed)
+	if err != nil {
+		result.Errors = append(result.Errors, model.ValidationMessage{Message: err.Error()})
+		return result
+	}
+	secured, stripped, rejected, err := this.checkImplementations(secured)
+	if err != nil {
+		result.Errors = append(result.Errors, model.ValidationMessage{Message: err.Error()})
+		return result
+	}
+	result.Errors = append(result.Errors, rejected...)
+	result.Warnings = append(result.Warnings, stripped...)
+	withId, err := SetProcessId(secured, depl.Id)
+	if err != nil {
+		result.Errors = append(result.Errors, model.ValidationMessage{Message: err.Error()})
+		return result
+	}
+	if !validateXml(withId) {
+		result.Errors = append(result.Errors, model.ValidationMessage{Message: "invalid bpmn"})
+		return result
+	}
+
+	errs, warnings := validateBpmnStructure(root)
+	result.Errors = append(result.Errors, errs...)
+	result.Warnings = append(result.Warnings, warnings...)
+	result.Errors = append(result.Errors, this.checkScripts(userId, root)...)
+	return result
+}
+
+type bpmnElement struct {
+	Name     xml.Name
+	Attr     []xml.Attr
+	Text     string
+	Line     int
+	Column   int
+	Parent   *bpmnElement
+	Children []*bpmnElement
+}
+
+func (this *bpmnElement) attr(space string, local string) string {
+	for _, attr := range this.Attr {
+		if attr.Name.Space == space && attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func (this *bpmnElement) is(space string, local string) bool {
+	return this.Name.Space == space && this.Name.Local == local
+}
+
+// message returns a ValidationMessage positioned at the element; the element id is the id of the element or of the nearest ancestor with an id
+func (this *bpmnElement) message(msg string) model.ValidationMessage {
+	id := ""
+	for element := this; element != nil && id == ""; element = element.Parent {
+		id = element.attr("", "id")
+	}
+	return model.ValidationMessage{
+		Message:   msg,
+		ElementId: id,
+		Line:      this.Line,
+		Column:    this.Column,
+	}
+}
+
+func (this *bpmnElement) walk(f func(element *bpmnElement)) {
+	f(this)
+	for _, child := range this.Children {
+		child.walk(f)
+	}
+}
+
+// parseBpmnElements reads the xml into a tree of elements with their start positions
+func parseBpmnElements(xmlStr string) (root *bpmnElement, err error) {
+	decoder := xml.NewDecoder(strings.NewReader(xmlStr))
+	stack := []*bpmnElement{}
+	for {
+		line, column := decoder.InputPos()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			element := &bpmnElement{Name: t.Name, Attr: t.Attr, Line: line, Column: column}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				element.Parent = parent
+				parent.Children = append(parent.Children, element)
+			} else if root == nil {
+				root = element
+			}
+			stack = append(stack, element)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += string(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.New("missing root element")
+	}
+	return root, nil
+}
+
+func validateBpmnStructure(root *bpmnElement) (errs []model.ValidationMessage, warnings []model.ValidationMessage) {
+	if !root.is(bpmnNamespace, "definitions") {
+		return append(errs, root.message("expected bpmn:definitions as root element")), warnings
+	}
+
+	executableProcesses := 0
+	for _, child := range root.Children {
+		if !child.is(bpmnNamespace, "process") {
+			continue
+		}
+		if child.attr("", "isExecutable") != "true" {
+			warnings = append(warnings, child.message("process is not executable and will not be deployed as process-definition"))
+			continue
+		}
+		executableProcesses++
+		hasStartEvent := false
+		for _, element := range child.Children {
+			if element.is(bpmnNamespace, "startEvent") {
+				hasStartEvent = true
+			}
+		}
+		if !hasStartEvent {
+			errs = append(errs, child.message("process has no start event"))
+		}
+	}
+	if executableProcesses == 0 {
+		errs = append(errs, root.message("no executable process found"))
+	}
+
+	root.walk(func(element *bpmnElement) {
+		if element.Name.Space == camundaNamespace && !knownCamundaElements[element.Name.Local] {
+			warnings = append(warnings, element.message("unknown camunda extension element camunda:"+element.Name.Local))
+		}
+		if element.Name.Space == bpmnNamespace {
+			errs = append(errs, validateSequenceFlows(element)...)
+		}
+	})
+	return errs, warnings
+}
+
+// validateSequenceFlows checks that the sequence flows of a process or sub-process connect elements of the same scope
+func validateSequenceFlows(scope *bpmnElement) (errs []model.ValidationMessage) {
+	ids := map[string]bool{}
+	for _, element := range scope.Children {
+		if id := element.attr("", "id"); id != "" {
+			ids[id] = true
+		}
+	}
+	for _, element := range scope.Children {
+		if !element.is(bpmnNamespace, "sequenceFlow") {
+			continue
+		}
+		for _, ref := range []string{"sourceRef", "targetRef"} {
+			value := element.attr("", ref)
+			if value == "" {
+				errs = append(errs, element.message("sequence flow without "+ref))
+			} else if !ids[value] {
+				errs = append(errs, element.message("sequence flow "+ref+" references unknown element "+value))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,448 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/webhooks"
+)
+
+var errWebhooksUnavailable = errors.New("webhooks are not available")
+
+var webhookEventTypes = []string{model.ProcessInstanceEventStarted, model.ProcessInstanceEventFinished, model.ProcessInstanceEventDeleted, model.ProcessInstanceEventIncident}
+
+const webhookDeliveryBatchSize = 100
+const webhookDeliveryConcurrency = 10
+const webhookMaxRetryBackoff = 24 * time.Hour
+const webhookEventQueueSize = 1000
+const webhookEnqueueAttempts = 3
+const webhookEnqueueRetryWait = time.Second
+
+func (this *Controller) ListWebhooks(userId string) (result []model.Webhook, err error, code int) {
+	if this.webhooks == nil {
+		return result, errWebhooksUnavailable, http.StatusNotImplemented
+	}
+	result, err = this.webhooks.List(userId)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	for i := range result {
+		result[i].Secret = ""
+	}
+	return result, nil, http.StatusOK
+}
+
+// CreateWebhook stores the webhook; the response contains the secret, which is generated if not set
+func (this *Controller) CreateWebhook(userId string, webhook model.Webhook) (result model.Webhook, err error, code int) {
+	if this.webhooks == nil {
+		return result, errWebhooksUnavailable, http.StatusNotImplemented
+	}
+	err = validateWebhook(webhook)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	if webhook.Secret == "" {
+		secret := make([]byte, 32)
+		_, err = rand.Read(secret)
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+	webhook.UserId = userId
+	result, err = this.webhooks.Create(webhook)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	this.refreshWebhookTenants()
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) GetWebhook(userId string, id string) (result model.Webhook, err error, code int) {
+	result, err, code = this.getWebhook(userId, id)
+	result.Secret = ""
+	return result, err, code
+}
+
+func (this *Controller) getWebhook(userId string, id string) (result model.Webhook, err error, code int) {
+	if this.webhooks == nil {
+		return result, errWebhooksUnavailable, http.StatusNotImplemented
+	}
+	result, exists, err := this.webhooks.Get(id)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !exists {
+		return result, errors.New("unknown webhook"), http.StatusNotFound
+	}
+	if result.UserId != userId {
+		return model.Webhook{}, errors.New("access denied"), http.StatusUnauthorized
+	}
+	return result, nil, http.StatusOK
+}
+
+// UpdateWebhook replaces the webhook; an empty secret keeps the current secret
+func (this *Controller) UpdateWebhook(userId string, id string, webhook model.Webhook) (result model.Webhook, err error, code int) {
+	existing, err, code := this.getWebhook(userId, id)
+	if err != nil {
+		return result, err, code
+	}
+	err = validateWebhook(webhook)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	webhook.Id = existing.Id
+	webhook.UserId = existing.UserId
+	result, exists, err := this.webhooks.Update(webhook)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !exists {
+		return result, errors.New("unknown webhook"), http.StatusNotFound
+	}
+	this.refreshWebhookTenants()
+	result.Secret = ""
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) DeleteWebhook(userId string, id string) (err error, code int) {
+	_, err, code = this.getWebhook(userId, id)
+	if err != nil {
+		return err, code
+	}
+	err = this.webhooks.Remove(id)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	this.refreshWebhookTenants()
+	return nil, http.StatusOK
+}
+
+// ListWebhookDeliveries returns the delivery log of the webhook, newest first
+func (this *Controller) ListWebhookDeliveries(userId string, id string, limit int, offset int) (result []model.WebhookDelivery, err error, code int) {
+	_, err, code = this.getWebhook(userId, id)
+	if err != nil {
+		return result, err, code
+	}
+	result, err = this.webhooks.ListDeliveries(id, limit, offset)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+func validateWebhook(webhook model.Webhook) error {
+	u, err := url.Parse(webhook.Url)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("invalid url: expect absolute http or https url")
+	}
+	for _, event := range webhook.Events {
+		if !slices.Contains(webhookEventTypes, event) {
+			return fmt.Errorf("unknown event type %v", event)
+		}
+	}
+	return nil
+}
+
+type webhookDelivery struct {
+	client     *http.Client
+	maxAttempt int64
+	backoff    time.Duration
+	lease      time.Duration
+	trigger    chan struct{}
+	events     chan model.ProcessInstanceEvent
+
+	tenants    []string
+	tenantsMux sync.RWMutex
+}
+
+// StartWebhookDelivery enqueues deliveries of events matching webhooks and sends pending deliveries every config.WebhookDeliveryInterval
+// and after new deliveries are enqueued. failed attempts are retried with exponential backoff starting at config.WebhookRetryBackoff.
+// events are received without blocking the publisher and enqueued by a separate worker; the webhooks are read per event,
+// so that webhooks created by other wrapper instances are used immediately.
+func (this *Controller) StartWebhookDelivery(ctx context.Context) error {
+	if this.webhooks == nil {
+		return nil
+	}
+	interval, err := time.ParseDuration(this.config.WebhookDeliveryInterval)
+	if err != nil {
+		return fmt.Errorf("invalid webhook_delivery_interval: %w", err)
+	}
+	timeout, err := time.ParseDuration(this.config.WebhookTimeout)
+	if err != nil {
+		return fmt.Errorf("invalid webhook_timeout: %w", err)
+	}
+	backoff, err := time.ParseDuration(this.config.WebhookRetryBackoff)
+	if err != nil {
+		return fmt.Errorf("invalid webhook_retry_backoff: %w", err)
+	}
+	retention, err := time.ParseDuration(this.config.WebhookDeliveryRetention)
+	if err != nil {
+		return fmt.Errorf("invalid webhook_delivery_retention: %w", err)
+	}
+	this.webhookDelivery = &webhookDelivery{
+		client:     newWebhookClient(timeout, this.config.WebhookAllowPrivateTargets),
+		maxAttempt: this.config.WebhookMaxAttempts,
+		backoff:    backoff,
+		lease:      2 * timeout,
+		trigger:    make(chan struct{}, 1),
+		events:     make(chan model.ProcessInstanceEvent, webhookEventQueueSize),
+	}
+	this.refreshWebhookTenants()
+	this.camunda.Events().ListenTenants(this.getWebhookTenants, this.receiveWebhookEvent)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-this.webhookDelivery.events:
+				this.enqueueWebhookDeliveriesWithRetry(ctx, event)
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		cleanup := time.NewTicker(time.Hour)
+		defer cleanup.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-cleanup.C:
+				err := this.webhooks.RemoveFinishedDeliveries(time.Now().Add(-retention))
+				if err != nil {
+					this.config.GetLogger().Error("unable to remove old webhook deliveries", "error", err)
+				}
+				continue
+			case <-ticker.C:
+				this.refreshWebhookTenants() //webhooks may be changed by other wrapper instances
+			case <-this.webhookDelivery.trigger:
+			}
+			this.deliverDueWebhooks()
+		}
+	}()
+	return nil
+}
+
+// newWebhookClient returns a http client, which does not connect to loopback, private or link-local addresses unless allowPrivateTargets is set.
+// the check is applied to the resolved address of every connection, including redirects.
+func newWebhookClient(timeout time.Duration, allowPrivateTargets bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivateTargets {
+		dialer.Control = func(network string, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+				return fmt.Errorf("webhook target %v is not allowed", host)
+			}
+			return nil
+		}
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: timeout},
+	}
+}
+
+// refreshWebhookTenants updates the users with webhooks, whose shards are polled for finished process-instances and incidents
+func (this *Controller) refreshWebhookTenants() {
+	if this.webhookDelivery == nil {
+		return
+	}
+	tenants, err := this.webhooks.ListUsers()
+	if err != nil {
+		this.config.GetLogger().Error("unable to list users with webhooks", "error", err)
+		return
+	}
+	this.webhookDelivery.tenantsMux.Lock()
+	defer this.webhookDelivery.tenantsMux.Unlock()
+	this.webhookDelivery.tenants = tenants
+}
+
+func (this *Controller) getWebhookTenants() []string {
+	this.webhookDelivery.tenantsMux.RLock()
+	defer this.webhookDelivery.tenantsMux.RUnlock()
+	return this.webhookDelivery.tenants
+}
+
+// receiveWebhookEvent queues the event without blocking; events are dropped if the queue is full
+func (this *Controller) receiveWebhookEvent(event model.ProcessInstanceEvent) {
+	select {
+	case this.webhookDelivery.events <- event:
+	default:
+		this.config.GetLogger().Error("webhook event queue full, drop event", "type", event.Type, "user", event.UserId, "processInstanceId", event.ProcessInstanceId)
+	}
+}
+
+// enqueueWebhookDeliveriesWithRetry retries enqueueWebhookDeliveries up to webhookEnqueueAttempts times;
+// Enqueue ignores deliveries already stored for the event, so retries do not duplicate deliveries
+func (this *Controller) enqueueWebhookDeliveriesWithRetry(ctx context.Context, event model.ProcessInstanceEvent) {
+	var err error
+	for attempt := 1; attempt <= webhookEnqueueAttempts; attempt++ {
+		err = this.enqueueWebhookDeliveries(event)
+		if err == nil {
+			return
+		}
+		this.config.GetLogger().Warn("unable to enqueue webhook deliveries", "user", event.UserId, "processInstanceId", event.ProcessInstanceId, "attempt", attempt, "error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(webhookEnqueueRetryWait):
+		}
+	}
+	this.config.GetLogger().Error("drop webhook event after failed enqueue attempts", "type", event.Type, "user", event.UserId, "processInstanceId", event.ProcessInstanceId, "error", err)
+}
+
+func (this *Controller) enqueueWebhookDeliveries(event model.ProcessInstanceEvent) (err error) {
+	list, err := this.webhooks.ListEnabled(event.UserId)
+	if err != nil {
+		return err
+	}
+	enqueued := false
+	defer func() {
+		if enqueued {
+			select {
+			case this.webhookDelivery.trigger <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	for _, webhook := range list {
+		if !webhooks.Matches(webhook, event) {
+			continue
+		}
+		err = this.webhooks.Enqueue(webhook.Id, event.Key(), event)
+		if err != nil {
+			return err
+		}
+		enqueued = true
+	}
+	return nil
+}
+
+func (this *Controller) deliverDueWebhooks() {
+	deliveries, err := this.webhooks.ClaimDue(time.Now(), webhookDeliveryBatchSize, this.webhookDelivery.lease)
+	if err != nil {
+		this.config.GetLogger().Error("unable to claim webhook deliveries", "error", err)
+		return
+	}
+	wg := sync.WaitGroup{}
+	semaphore := make(chan struct{}, webhookDeliveryConcurrency)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			this.deliverWebhook(delivery)
+		}()
+	}
+	wg.Wait()
+}
+
+func (this *Controller) deliverWebhook(delivery model.WebhookDelivery) {
+	statusCode, err := this.sendWebhook(delivery)
+	state := model.WebhookDeliveryDelivered
+	errMsg := ""
+	var next *time.Time
+	if err != nil {
+		errMsg = err.Error()
+		state = model.WebhookDeliveryFailed
+		if int64(delivery.Attempts+1) < this.webhookDelivery.maxAttempt {
+			state = model.WebhookDeliveryPending
+			t := time.Now().Add(webhookRetryBackoff(this.webhookDelivery.backoff, delivery.Attempts))
+			next = &t
+		}
+		this.config.GetLogger().Warn("unable to deliver webhook", "webhook", delivery.WebhookId, "delivery", delivery.Id, "attempt", delivery.Attempts+1, "error", err)
+	}
+	err = this.webhooks.SetDeliveryResult(delivery.Id, state, statusCode, errMsg, next)
+	if err != nil {
+		this.config.GetLogger().Error("unable to store webhook delivery result", "delivery", delivery.Id, "error", err)
+	}
+}
+
+// webhookRetryBackoff doubles the backoff with every previous attempt up to webhookMaxRetryBackoff
+func webhookRetryBackoff(backoff time.Duration, previousAttempts int) time.Duration {
+	for i := 0; i < previousAttempts && backoff < webhookMaxRetryBackoff; i++ {
+		backoff = backoff * 2
+	}
+	return min(backoff, webhookMaxRetryBackoff)
+}
+
+func (this *Controller) sendWebhook(delivery model.WebhookDelivery) (statusCode int, err error) {
+	webhook, exists, err := this.webhooks.Get(delivery.WebhookId)
+	if err != nil {
+		return 0, err
+	}
+	if !exists || !webhook.Enabled {
+		return 0, errors.New("webhook is disabled")
+	}
+	body, err := json.Marshal(model.WebhookPayload{
+		DeliveryId: delivery.Id,
+		WebhookId:  webhook.Id,
+		Event:      delivery.Event,
+	})
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequest(http.MethodPost, webhook.Url, bytes.NewBuffer(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhooks.TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(webhooks.SignatureHeader, webhooks.Sign(webhook.Secret, timestamp, body))
+	req.Header.Set(webhooks.DeliveryHeader, delivery.Id)
+	req.Header.Set(webhooks.EventHeader, delivery.Event.Type)
+	resp, err := this.webhookDelivery.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected statuscode %v", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+// Events distributes process-instance events to subscriptions of the tenant and to listeners of all tenants.
+// Publish never blocks on subscriptions: events for subscriptions with a full buffer are dropped.
+// a nil *Events ignores all events.
+type Events struct {
+	mux           sync.RWMutex
+	closed        bool
+	subscriptions map[string]map[*subscription]bool
+	listeners     []listener
+}
+
+type subscription struct {
+	events chan model.ProcessInstanceEvent
+}
+
+type listener struct {
+	tenants func() []string //nil for all tenants
+	receive func(event model.ProcessInstanceEvent)
+}
+
+func New() *Events {
+	return &Events{subscriptions: map[string]map[*subscription]bool{}}
+}
+
+// Publish sends the event to all subscriptions of event.UserId and to all listeners
+func (this *Events) Publish(event model.ProcessInstanceEvent) {
+	if this == nil {
+		return
+	}
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	for sub := range this.subscriptions[event.UserId] {
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+	for _, listener := range this.listeners {
+		listener.receive(event)
+	}
+}
+
+// Subscribe returns the events of the user until cancel is called or the Events are closed, in which case the channel is closed
+func (this *Events) Subscribe(userId string, buffer int) (events <-chan model.ProcessInstanceEvent, cancel func()) {
+	sub := &subscription{events: make(chan model.ProcessInstanceEvent, buffer)}
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.closed {
+		close(sub.events)
+		return sub.events, func() {}
+	}
+	if this.subscriptions[userId] == nil {
+		this.subscriptions[userId] = map[*subscription]bool{}
+	}
+	this.subscriptions[userId][sub] = true
+	return sub.events, func() {
+		this.mux.Lock()
+		defer this.mux.Unlock()
+		if this.subscriptions[userId][sub] {
+			delete(this.subscriptions[userId], sub)
+			if len(this.subscriptions[userId]) == 0 {
+				delete(this.subscriptions, userId)
+			}
+			close(sub.events)
+		}
+	}
+}
+
+// Listen registers a listener for the events of all tenants; listeners are called synchronously by Publish and should not block
+func (this *Events) Listen(receive func(event model.ProcessInstanceEvent)) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.listeners = append(this.listeners, listener{receive: receive})
+}
+
+// ListenTenants registers a listener like Listen, which is only interested in the events of the users returned by tenants.
+// other events are still passed to the listener, but Tenants only reports the users returned by tenants for this listener.
+func (this *Events) ListenTenants(tenants func() []string, receive func(event model.ProcessInstanceEvent)) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.listeners = append(this.listeners, listener{tenants: tenants, receive: receive})
+}
+
+// Tenants returns the users with subscriptions or interested listeners; all is true if listeners for all tenants are registered
+func (this *Events) Tenants() (all bool, tenants []string) {
+	if this == nil {
+		return false, nil
+	}
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	for userId := range this.subscriptions {
+		tenants = append(tenants, userId)
+	}
+	for _, listener := range this.listeners {
+		if listener.tenants == nil {
+			all = true
+		} else {
+			tenants = append(tenants, listener.tenants()...)
+		}
+	}
+	slices.Sort(tenants)
+	return all, slices.Compact(tenants)
+}
+
+// Close ends all subscriptions
+func (this *Events) Close() {
+	if this == nil {
+		return
+	}
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.closed = true
+	for _, subs := range this.subscriptions {
+		for sub := range subs {
+			close(sub.events)
+		}
+	}
+	this.subscriptions = map[string]map[*subscription]bool{}
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package idempotency
+
+import (
+	"database/sql"
+
+	_ "github.com/lib/pq"
+)
+
+var CreateIdempotencyTable = `CREATE TABLE IF NOT EXISTS IdempotencyKey (
+	UserId				VARCHAR(255) NOT NULL,
+	RequestKey			VARCHAR(255) NOT NULL,
+	RequestHash			VARCHAR(64) NOT NULL,
+	State				VARCHAR(64) NOT NULL,
+	InstanceId			VARCHAR(255) NOT NULL DEFAULT '',
+	ResponseCode		INTEGER NOT NULL DEFAULT 0,
+	ContentType			VARCHAR(255) NOT NULL DEFAULT '',
+	ResponseBody		BYTEA,
+	CreatedAt			TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+	PRIMARY KEY (UserId, RequestKey)
+);
+CREATE INDEX IF NOT EXISTS idempotency_created_index ON IdempotencyKey (CreatedAt);
+`
+
+func InitDb(pgConn string) (db *sql.DB, err error) {
+	db, err = sql.Open("postgres", pgConn)
+	if err != nil {
+		return
+	}
+	_, err = db.Exec(CreateIdempotencyTable)
+	return db, err
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package idempotency
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+const (
+	StatePending = "pending"
+	StateDone    = "done"
+)
+
+func New(pgConn string) (result *Idempotency, err error) {
+	result = &Idempotency{}
+	result.db, err = InitDb(pgConn)
+	return
+}
+
+// Idempotency stores idempotency keys of process starts with the response of the first successful start
+type Idempotency struct {
+	db *sql.DB
+}
+
+// Record is the stored state of an idempotency key
+type Record struct {
+	RequestHash string
+	State       string
+	InstanceId  string
+	Response    model.IdempotentResponse
+	CreatedAt   time.Time
+}
+
+// Reserve stores the key as pending; if the key is already known (and not older than window), the existing record is returned with reserved=false.
+// pending keys older than pendingLease are treated as abandoned (e.g. by a crash during the start) and may be reserved again
+func (this *Idempotency) Reserve(userId string, key string, requestHash string, window time.Duration, pendingLease time.Duration) (existing Record, reserved bool, err error) {
+	now := time.Now()
+	_, err = this.db.Exec(`DELETE FROM IdempotencyKey WHERE UserId = $1 AND RequestKey = $2 AND (CreatedAt < $3 OR (State = $4 AND CreatedAt < $5));`,
+		userId, key, now.Add(-window), StatePending, now.Add(-pendingLease))
+	if err != nil {
+		return existing, false, err
+	}
+	result, err := this.db.Exec(`INSERT INTO IdempotencyKey (UserId, RequestKey, RequestHash, State) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING;`, userId, key, requestHash, StatePending)
+	if err != nil {
+		return existing, false, err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return existing, false, err
+	}
+	if count == 1 {
+		return existing, true, nil
+	}
+	err = this.db.QueryRow(`SELECT RequestHash, State, InstanceId, ResponseCode, ContentType, ResponseBody, CreatedAt FROM IdempotencyKey WHERE UserId = $1 AND RequestKey = $2;`, userId, key).
+		Scan(&existing.RequestHash, &existing.State, &existing.InstanceId, &existing.Response.Code, &existing.Response.ContentType, &existing.Response.Body, &existing.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		//removed between insert and select
+		return this.Reserve(userId, key, requestHash, window, pendingLease)
+	}
+	return existing, false, err
+}
+
+// Complete stores the response of the start for a reserved key
+func (this *Idempotency) Complete(userId string, key string, instanceId string, response model.IdempotentResponse) (err error) {
+	_, err = this.db.Exec(`UPDATE IdempotencyKey SET State = $3, InstanceId = $4, ResponseCode = $5, ContentType = $6, ResponseBody = $7 WHERE UserId = $1 AND RequestKey = $2;`,
+		userId, key, StateDone, instanceId, response.Code, response.ContentType, response.Body)
+	return err
+}
+
+// Release removes a reserved key, to allow retries of failed starts
+func (this *Idempotency) Release(userId string, key string) (err error) {
+	_, err = this.db.Exec(`DELETE FROM IdempotencyKey WHERE UserId = $1 AND RequestKey = $2 AND State = $3;`, userId, key, StatePending)
+	return err
+}
+
+// RemoveExpired removes keys older than window
+func (this *Idempotency) RemoveExpired(window time.Duration) (err error) {
+	_, err = this.db.Exec(`DELETE FROM IdempotencyKey WHERE CreatedAt < $1;`, time.Now().Add(-window))
+	return err
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobs
+
+import (
+	"database/sql"
+
+	_ "github.com/lib/pq"
+)
+
+var CreateJobTable = `CREATE TABLE IF NOT EXISTS DeploymentJob (
+	ID					VARCHAR(255) PRIMARY KEY,
+	VirtualId			VARCHAR(255) NOT NULL,
+	UserId				VARCHAR(255) NOT NULL,
+	Message				TEXT NOT NULL,
+	Step				VARCHAR(64) NOT NULL,
+	State				VARCHAR(64) NOT NULL,
+	DeploymentId		VARCHAR(255) NOT NULL DEFAULT '',
+	Error				TEXT NOT NULL DEFAULT '',
+	CreatedAt			TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+	UpdatedAt			TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS job_state_index ON DeploymentJob (State, UpdatedAt);
+CREATE INDEX IF NOT EXISTS job_did_index ON DeploymentJob (DeploymentId);
+ALTER TABLE DeploymentJob ADD COLUMN IF NOT EXISTS CallbackUrl TEXT NOT NULL DEFAULT '';
+`
+
+func InitDb(pgConn string) (db *sql.DB, err error) {
+	db, err = sql.Open("postgres", pgConn)
+	if err != nil {
+		return
+	}
+	_, err = db.Exec(CreateJobTable)
+	return db, err
+}
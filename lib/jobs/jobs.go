@@ -0,0 +1,157 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobs
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/google/uuid"
+)
+
+func New(pgConn string) (jobs *Jobs, err error) {
+	jobs = &Jobs{}
+	jobs.db, err = InitDb(pgConn)
+	return
+}
+
+// Jobs stores the progress of deployments, to resume or compensate deployments interrupted by a restart
+type Jobs struct {
+	db *sql.DB
+}
+
+const jobColumns = `ID, VirtualId, UserId, Message, Step, State, DeploymentId, Error, CallbackUrl, CreatedAt, UpdatedAt`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanJob(row scanner) (job model.DeploymentJob, err error) {
+	var msg string
+	err = row.Scan(&job.Id, &job.DeploymentId, &job.UserId, &msg, &job.Step, &job.State, &job.EngineDeploymentId, &job.Error, &job.CallbackUrl, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return job, err
+	}
+	err = json.Unmarshal([]byte(msg), &job.Message)
+	return job, err
+}
+
+// Create stores a new job with the given state (model.DeploymentJobStateRunning or model.DeploymentJobStateQueued)
+func (this *Jobs) Create(depl model.DeploymentMessage, state string, callbackUrl string) (job model.DeploymentJob, err error) {
+	msg, err := json.Marshal(depl)
+	if err != nil {
+		return job, err
+	}
+	row := this.db.QueryRow(`INSERT INTO DeploymentJob (ID, VirtualId, UserId, Message, Step, State, CallbackUrl) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING `+jobColumns+`;`,
+		uuid.NewString(), depl.Id, depl.UserId, string(msg), model.DeploymentJobStepCreated, state, callbackUrl)
+	return scanJob(row)
+}
+
+// CreateIfIdle stores a new job like Create, unless a queued or running job exists for the vid of depl, in which case created is false.
+// the check and the insert are serialized per vid with an advisory lock, so that concurrent wrapper instances do not create two jobs.
+func (this *Jobs) CreateIfIdle(depl model.DeploymentMessage, state string, callbackUrl string) (job model.DeploymentJob, created bool, err error) {
+	msg, err := json.Marshal(depl)
+	if err != nil {
+		return job, false, err
+	}
+	tx, err := this.db.Begin()
+	if err != nil {
+		return job, false, err
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1));`, "deployment-job."+depl.Id)
+	if err != nil {
+		return job, false, err
+	}
+	active := false
+	err = tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM DeploymentJob WHERE VirtualId = $1 AND State IN ($2, $3));`,
+		depl.Id, model.DeploymentJobStateQueued, model.DeploymentJobStateRunning).Scan(&active)
+	if err != nil {
+		return job, false, err
+	}
+	if active {
+		return job, false, nil
+	}
+	job, err = scanJob(tx.QueryRow(`INSERT INTO DeploymentJob (ID, VirtualId, UserId, Message, Step, State, CallbackUrl) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING `+jobColumns+`;`,
+		uuid.NewString(), depl.Id, depl.UserId, string(msg), model.DeploymentJobStepCreated, state, callbackUrl))
+	if err != nil {
+		return job, false, err
+	}
+	return job, true, tx.Commit()
+}
+
+// Update stores step, state, engine deployment id and error of the job
+func (this *Jobs) Update(job *model.DeploymentJob) (err error) {
+	err = this.db.QueryRow(`UPDATE DeploymentJob SET Step = $2, State = $3, DeploymentId = $4, Error = $5, UpdatedAt = now() WHERE ID = $1 RETURNING UpdatedAt;`,
+		job.Id, job.Step, job.State, job.EngineDeploymentId, job.Error).Scan(&job.UpdatedAt)
+	return err
+}
+
+// Touch updates UpdatedAt of a running job, to prevent ClaimStale while the job makes progress
+func (this *Jobs) Touch(id string) (err error) {
+	_, err = this.db.Exec(`UPDATE DeploymentJob SET UpdatedAt = now() WHERE ID = $1 AND State = $2;`, id, model.DeploymentJobStateRunning)
+	return err
+}
+
+func (this *Jobs) Get(id string) (job model.DeploymentJob, exists bool, err error) {
+	job, err = scanJob(this.db.QueryRow(`SELECT `+jobColumns+` FROM DeploymentJob WHERE ID = $1;`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return job, false, nil
+	}
+	if err != nil {
+		return job, false, err
+	}
+	return job, true, nil
+}
+
+// ClaimStale returns a running or queued job without updates since staleTimeout and marks it as updated and running,
+// so that concurrent wrapper instances do not claim the same job
+func (this *Jobs) ClaimStale(staleTimeout time.Duration) (job model.DeploymentJob, exists bool, err error) {
+	job, err = scanJob(this.db.QueryRow(`UPDATE DeploymentJob SET UpdatedAt = now(), State = $1 WHERE ID = (
+			SELECT ID FROM DeploymentJob WHERE State IN ($1, $2) AND UpdatedAt < $3 ORDER BY CreatedAt LIMIT 1 FOR UPDATE SKIP LOCKED
+		) RETURNING `+jobColumns+`;`, model.DeploymentJobStateRunning, model.DeploymentJobStateQueued, time.Now().Add(-staleTimeout)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return job, false, nil
+	}
+	if err != nil {
+		return job, false, err
+	}
+	return job, true, nil
+}
+
+// ClaimQueued marks a queued job as running; returns false if the job is no longer queued (e.g. claimed by ClaimStale)
+func (this *Jobs) ClaimQueued(job *model.DeploymentJob) (claimed bool, err error) {
+	err = this.db.QueryRow(`UPDATE DeploymentJob SET State = $2, UpdatedAt = now() WHERE ID = $1 AND State = $3 RETURNING UpdatedAt;`,
+		job.Id, model.DeploymentJobStateRunning, model.DeploymentJobStateQueued).Scan(&job.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	job.State = model.DeploymentJobStateRunning
+	return true, nil
+}
+
+// RemoveFinished removes done and failed jobs older than retention
+func (this *Jobs) RemoveFinished(retention time.Duration) (err error) {
+	_, err = this.db.Exec(`DELETE FROM DeploymentJob WHERE State IN ($1, $2) AND UpdatedAt < $3;`, model.DeploymentJobStateDone, model.DeploymentJobStateFailed, time.Now().Add(-retention))
+	return err
+}
@@ -6,11 +6,16 @@ import (
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/camunda"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/controller"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/idempotency"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/jobs"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/metrics"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/processio"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/publisher"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/schedules"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/shards"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/shards/cache"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/vid"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/webhooks"
 )
 
 func Wrapper(parentCtx context.Context, config configuration.Config) (err error) {
@@ -21,6 +26,11 @@ func Wrapper(parentCtx context.Context, config configuration.Config) (err error)
 		}
 	}()
 
+	err = controller.ValidateImplementationCheck(config.ImplementationCheck)
+	if err != nil {
+		return err
+	}
+
 	v, err := vid.New(config.WrapperDb)
 	if err != nil {
 		return err
@@ -37,7 +47,72 @@ func Wrapper(parentCtx context.Context, config configuration.Config) (err error)
 
 	m := metrics.New().Serve(ctx, config.MetricsPort)
 
-	ctrl := controller.New(config, c, v, processIo)
+	j, err := jobs.New(config.WrapperDb)
+	if err != nil {
+		return err
+	}
+
+	idem, err := idempotency.New(config.WrapperDb)
+	if err != nil {
+		return err
+	}
+
+	sched, err := schedules.New(config.WrapperDb)
+	if err != nil {
+		return err
+	}
+
+	hooks, err := webhooks.New(config.WrapperDb)
+	if err != nil {
+		return err
+	}
+
+	var changePublisher controller.Publisher
+	if config.KafkaUrl != "" {
+		changePublisher = publisher.NewKafka(ctx, config)
+	}
+
+	ctrl := controller.New(config, c, v, processIo).
+		SetJobs(j).
+		SetIdempotency(idem).
+		SetSchedules(sched).
+		SetWebhooks(hooks).
+		SetPublisher(changePublisher)
+
+	err = ctrl.StartDeploymentJobRecovery(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = ctrl.StartDeploymentWorkers(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = ctrl.StartIdempotencyKeyCleanup(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = ctrl.StartScheduler(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = ctrl.StartInstanceEventPoller(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = ctrl.StartWebhookDelivery(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = ctrl.StartChangePublication(ctx)
+	if err != nil {
+		return err
+	}
 
 	err = api.Start(ctx, config, c, ctrl, m)
 	if err != nil {
@@ -133,3 +133,152 @@ type HistoricProcessInstancesWithTotal = struct {
 	Total int64                    `json:"total"`
 	Data  HistoricProcessInstances `json:"data"`
 }
+
+type ProcessInstancesWithTotal = struct {
+	Total int64            `json:"total"`
+	Data  ProcessInstances `json:"data"`
+}
+
+type ProcessInstanceQuery struct {
+	Limit        int
+	Offset       int
+	SortBy       string
+	SortOrder    string
+	BusinessKey  string
+	DefinitionId string
+	DeploymentId string //vid
+	Suspended    *bool
+}
+
+// /engine-rest/deployment/"+url.QueryEscape(id)+"/resources
+type DeploymentResource struct {
+	Id           string `json:"id"`
+	Name         string `json:"name"`
+	DeploymentId string `json:"deploymentId"`
+}
+
+type DeploymentResources = []DeploymentResource
+
+// /engine-rest/process-instance/"+url.QueryEscape(id)+"/variables
+type VariableModifications struct {
+	Modifications VariableMap `json:"modifications,omitempty"`
+	Deletions     []string    `json:"deletions,omitempty"`
+}
+
+// /engine-rest/process-instance/"+url.QueryEscape(id)+"/activity-instances
+type ActivityInstance struct {
+	Id                       string               `json:"id"`
+	ParentActivityInstanceId string               `json:"parentActivityInstanceId"`
+	ActivityId               string               `json:"activityId"`
+	ActivityType             string               `json:"activityType"`
+	ActivityName             string               `json:"activityName"`
+	ProcessInstanceId        string               `json:"processInstanceId"`
+	ProcessDefinitionId      string               `json:"processDefinitionId"`
+	ChildActivityInstances   []ActivityInstance   `json:"childActivityInstances"`
+	ChildTransitionInstances []TransitionInstance `json:"childTransitionInstances"`
+	ExecutionIds             []string             `json:"executionIds"`
+	IncidentIds              []string             `json:"incidentIds"`
+}
+
+// transition instances represent executions that are waiting before or after an activity (async continuation)
+type TransitionInstance struct {
+	Id                       string   `json:"id"`
+	ParentActivityInstanceId string   `json:"parentActivityInstanceId"`
+	ActivityId               string   `json:"activityId"`
+	ActivityType             string   `json:"activityType"`
+	ActivityName             string   `json:"activityName"`
+	ProcessInstanceId        string   `json:"processInstanceId"`
+	ProcessDefinitionId      string   `json:"processDefinitionId"`
+	ExecutionId              string   `json:"executionId"`
+	IncidentIds              []string `json:"incidentIds"`
+}
+
+// /engine-rest/history/activity-instance?processInstanceId="+url.QueryEscape(id)
+type HistoricActivityInstance struct {
+	Id                       string  `json:"id"`
+	ParentActivityInstanceId string  `json:"parentActivityInstanceId"`
+	ActivityId               string  `json:"activityId"`
+	ActivityName             string  `json:"activityName"`
+	ActivityType             string  `json:"activityType"`
+	ProcessDefinitionKey     string  `json:"processDefinitionKey"`
+	ProcessDefinitionId      string  `json:"processDefinitionId"`
+	ProcessInstanceId        string  `json:"processInstanceId"`
+	ExecutionId              string  `json:"executionId"`
+	TaskId                   string  `json:"taskId"`
+	CalledProcessInstanceId  string  `json:"calledProcessInstanceId"`
+	StartTime                string  `json:"startTime"`
+	EndTime                  string  `json:"endTime"`
+	DurationInMillis         float64 `json:"durationInMillis"`
+	Canceled                 bool    `json:"canceled"`
+	CompleteScope            bool    `json:"completeScope"`
+	TenantId                 string  `json:"tenantId"`
+}
+
+type HistoricActivityInstances = []HistoricActivityInstance
+
+const (
+	IncidentTypeFailedJob          = "failedJob"
+	IncidentTypeFailedExternalTask = "failedExternalTask"
+)
+
+// /engine-rest/incident
+type Incident struct {
+	Id                  string `json:"id"`
+	ProcessDefinitionId string `json:"processDefinitionId"`
+	ProcessInstanceId   string `json:"processInstanceId"`
+	ExecutionId         string `json:"executionId"`
+	IncidentTimestamp   string `json:"incidentTimestamp"`
+	IncidentType        string `json:"incidentType"`
+	ActivityId          string `json:"activityId"`
+	FailedActivityId    string `json:"failedActivityId"`
+	CauseIncidentId     string `json:"causeIncidentId"`
+	RootCauseIncidentId string `json:"rootCauseIncidentId"`
+	Configuration       string `json:"configuration"` //id of the failed job or external task
+	IncidentMessage     string `json:"incidentMessage"`
+	TenantId            string `json:"tenantId"`
+	JobDefinitionId     string `json:"jobDefinitionId"`
+	Annotation          string `json:"annotation"`
+}
+
+type Incidents = []Incident
+
+type IncidentQuery struct {
+	Limit             int
+	Offset            int
+	ProcessInstanceId string
+	DefinitionId      string
+	DeploymentId      string //vid
+	IncidentType      string
+}
+
+// /engine-rest/process-instance/"+url.QueryEscape(id)+"/modification
+type ProcessInstanceModification struct {
+	SkipCustomListeners bool                      `json:"skipCustomListeners,omitempty"`
+	SkipIoMappings      bool                      `json:"skipIoMappings,omitempty"`
+	Instructions        []ModificationInstruction `json:"instructions"`
+	Annotation          string                    `json:"annotation,omitempty"`
+}
+
+const (
+	ModificationStartBeforeActivity = "startBeforeActivity"
+	ModificationStartAfterActivity  = "startAfterActivity"
+	ModificationStartTransition     = "startTransition"
+	ModificationCancel              = "cancel"
+)
+
+type ModificationInstruction struct {
+	Type                       string      `json:"type"`
+	ActivityId                 string      `json:"activityId,omitempty"`
+	TransitionId               string      `json:"transitionId,omitempty"`
+	ActivityInstanceId         string      `json:"activityInstanceId,omitempty"`
+	TransitionInstanceId       string      `json:"transitionInstanceId,omitempty"`
+	AncestorActivityInstanceId string      `json:"ancestorActivityInstanceId,omitempty"`
+	Variables                  VariableMap `json:"variables,omitempty"`
+}
+
+type RestartRequest struct {
+	StartActivityId     string `json:"startActivityId,omitempty"` //defaults to the start activity of the historic process-instance
+	SkipCustomListeners bool   `json:"skipCustomListeners,omitempty"`
+	SkipIoMappings      bool   `json:"skipIoMappings,omitempty"`
+	WithoutBusinessKey  bool   `json:"withoutBusinessKey,omitempty"`
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+const (
+	ChangeEventDeploymentCreated = "deployment.created"
+	ChangeEventDeploymentDeleted = "deployment.deleted"
+	ChangeEventInstanceStarted   = "instance.started"
+	ChangeEventInstanceDeleted   = "instance.deleted"
+)
+
+// ChangeEvent describes a change of a deployment or process-instance published to downstream services
+type ChangeEvent struct {
+	Type                string    `json:"type"`
+	UserId              string    `json:"user_id"`
+	DeploymentId        string    `json:"deployment_id,omitempty"` //vid of the deployment, if known
+	DeploymentName      string    `json:"deployment_name,omitempty"`
+	ProcessInstanceId   string    `json:"process_instance_id,omitempty"`
+	ProcessDefinitionId string    `json:"process_definition_id,omitempty"`
+	BusinessKey         string    `json:"business_key,omitempty"`
+	Time                time.Time `json:"time"`
+}
+
+// Key groups events of the same deployment, or of the same process-instance if the deployment is unknown
+func (this ChangeEvent) Key() string {
+	if this.DeploymentId != "" {
+		return this.DeploymentId
+	}
+	return this.ProcessInstanceId
+}
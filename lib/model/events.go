@@ -0,0 +1,45 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+const (
+	ProcessInstanceEventStarted  = "started"
+	ProcessInstanceEventFinished = "finished"
+	ProcessInstanceEventDeleted  = "deleted"
+	ProcessInstanceEventIncident = "incident"
+)
+
+// ProcessInstanceEvent describes a lifecycle change of a process-instance
+type ProcessInstanceEvent struct {
+	Type                string    `json:"type"`
+	ProcessInstanceId   string    `json:"process_instance_id"`
+	ProcessDefinitionId string    `json:"process_definition_id,omitempty"`
+	DeploymentId        string    `json:"deployment_id,omitempty"` //vid of the deployment, if known
+	BusinessKey         string    `json:"business_key,omitempty"`
+	IncidentId          string    `json:"incident_id,omitempty"`
+	IncidentMessage     string    `json:"incident_message,omitempty"`
+	Time                time.Time `json:"time"`
+
+	UserId string `json:"-"`
+}
+
+// Key identifies the event independent of the wrapper instance publishing it
+func (this ProcessInstanceEvent) Key() string {
+	return this.Type + "/" + this.ProcessInstanceId + "/" + this.IncidentId
+}
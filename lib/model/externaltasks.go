@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+// /engine-rest/external-task/fetchAndLock
+// topics are restricted to the tenant of the caller
+type ExternalTaskFetchRequest struct {
+	WorkerId             string              `json:"workerId"`
+	MaxTasks             int64               `json:"maxTasks"`
+	UsePriority          bool                `json:"usePriority,omitempty"`
+	AsyncResponseTimeout int64               `json:"asyncResponseTimeout,omitempty"` //long polling timeout in ms
+	Topics               []ExternalTaskTopic `json:"topics"`
+}
+
+type ExternalTaskTopic struct {
+	TopicName            string   `json:"topicName"`
+	LockDuration         int64    `json:"lockDuration"` //ms
+	Variables            []string `json:"variables,omitempty"`
+	LocalVariables       bool     `json:"localVariables,omitempty"`
+	BusinessKey          string   `json:"businessKey,omitempty"`
+	ProcessDefinitionId  string   `json:"processDefinitionId,omitempty"`
+	ProcessDefinitionKey string   `json:"processDefinitionKey,omitempty"`
+	DeserializeValues    bool     `json:"deserializeValues,omitempty"`
+}
+
+type LockedExternalTask struct {
+	Id                   string      `json:"id"`
+	TopicName            string      `json:"topicName"`
+	WorkerId             string      `json:"workerId"`
+	LockExpirationTime   string      `json:"lockExpirationTime"`
+	ActivityId           string      `json:"activityId"`
+	ActivityInstanceId   string      `json:"activityInstanceId"`
+	ExecutionId          string      `json:"executionId"`
+	ProcessInstanceId    string      `json:"processInstanceId"`
+	ProcessDefinitionId  string      `json:"processDefinitionId"`
+	ProcessDefinitionKey string      `json:"processDefinitionKey"`
+	BusinessKey          string      `json:"businessKey"`
+	Retries              *int64      `json:"retries"`
+	ErrorMessage         string      `json:"errorMessage"`
+	ErrorDetails         string      `json:"errorDetails"`
+	Priority             int64       `json:"priority"`
+	TenantId             string      `json:"tenantId"`
+	Variables            VariableMap `json:"variables"`
+}
+
+// /engine-rest/external-task/{id}
+type ExternalTask struct {
+	Id                  string `json:"id"`
+	TopicName           string `json:"topicName"`
+	WorkerId            string `json:"workerId"`
+	ProcessInstanceId   string `json:"processInstanceId"`
+	ProcessDefinitionId string `json:"processDefinitionId"`
+	TenantId            string `json:"tenantId"`
+}
+
+// /engine-rest/external-task/{id}/complete
+type ExternalTaskCompleteRequest struct {
+	WorkerId       string      `json:"workerId"`
+	Variables      VariableMap `json:"variables,omitempty"`
+	LocalVariables VariableMap `json:"localVariables,omitempty"`
+}
+
+// /engine-rest/external-task/{id}/failure
+type ExternalTaskFailureRequest struct {
+	WorkerId     string `json:"workerId"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	ErrorDetails string `json:"errorDetails,omitempty"`
+	Retries      int64  `json:"retries"`
+	RetryTimeout int64  `json:"retryTimeout"` //ms
+}
+
+// /engine-rest/external-task/{id}/extendLock
+type ExternalTaskExtendLockRequest struct {
+	WorkerId    string `json:"workerId"`
+	NewDuration int64  `json:"newDuration"` //ms
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+// steps of a deployment job; each step is recorded after it is finished
+const (
+	DeploymentJobStepCreated          = "created"
+	DeploymentJobStepCleaned          = "cleaned"
+	DeploymentJobStepDeployed         = "deployed"
+	DeploymentJobStepIncidentHandling = "incident_handling"
+	DeploymentJobStepLinked           = "linked"
+	DeploymentJobStepDone             = "done"
+)
+
+// failed jobs are compensated: changes of finished steps are removed
+const (
+	DeploymentJobStateQueued  = "queued"
+	DeploymentJobStateRunning = "running"
+	DeploymentJobStateDone    = "done"
+	DeploymentJobStateFailed  = "failed"
+)
+
+type DeploymentJob struct {
+	Id           string    `json:"id"`
+	DeploymentId string    `json:"deployment_id"`
+	UserId       string    `json:"user_id"`
+	Step         string    `json:"step"`
+	State        string    `json:"state"`
+	Error        string    `json:"error,omitempty"`
+	CallbackUrl  string    `json:"callback_url,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	EngineDeploymentId string            `json:"-"`
+	Message            DeploymentMessage `json:"-"`
+}
@@ -16,7 +16,11 @@
 
 package model
 
-import "github.com/SENERGY-Platform/models/go/models"
+import (
+	"time"
+
+	"github.com/SENERGY-Platform/models/go/models"
+)
 
 type Deployment struct {
 	Id               string            `json:"id"`
@@ -34,3 +38,92 @@ type DeploymentMessage struct {
 	UserId string `json:"user_id"`
 	Source string `json:"source"` //optional
 }
+
+type SuspensionState struct {
+	Suspended bool `json:"suspended"`
+}
+
+// DeploymentVersion describes a deployment stored for a vid.
+// only the active version is used for new process-instances; inactive versions are kept for rollbacks.
+type DeploymentVersion struct {
+	Version      int       `json:"version"`
+	CreatedAt    time.Time `json:"created_at"`
+	Active       bool      `json:"active"`
+	DeploymentId string    `json:"-"`
+}
+
+type DeploymentValidationResult struct {
+	Valid    bool                `json:"valid"`
+	Errors   []ValidationMessage `json:"errors"`
+	Warnings []ValidationMessage `json:"warnings"`
+}
+
+// ValidationMessage describes an error or warning found in a bpmn xml.
+// Line and Column reference the start of the related element, if known.
+type ValidationMessage struct {
+	Message   string `json:"message"`
+	ElementId string `json:"element_id,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+}
+
+// JsonSchema is the subset of json schema used to describe process start parameters
+type JsonSchema struct {
+	Schema     string                `json:"$schema,omitempty"`
+	Type       string                `json:"type,omitempty"`
+	Title      string                `json:"title,omitempty"`
+	Default    interface{}           `json:"default,omitempty"`
+	Enum       []interface{}         `json:"enum,omitempty"`
+	EnumLabels map[string]string     `json:"x-enum-labels,omitempty"` //label by enum value
+	ReadOnly   bool                  `json:"readOnly,omitempty"`
+	Minimum    *float64              `json:"minimum,omitempty"`
+	Maximum    *float64              `json:"maximum,omitempty"`
+	MinLength  *int64                `json:"minLength,omitempty"`
+	MaxLength  *int64                `json:"maxLength,omitempty"`
+	Properties map[string]JsonSchema `json:"properties,omitempty"`
+	Required   []string              `json:"required,omitempty"`
+}
+
+// StartRequest is the body of the POST start endpoints
+type StartRequest struct {
+	BusinessKey           string              `json:"business_key,omitempty"`
+	Variables             map[string]Variable `json:"variables,omitempty"` //type and valueInfo are optional
+	WithVariablesInReturn bool                `json:"with_variables_in_return,omitempty"`
+	IdempotencyKey        string              `json:"idempotency_key,omitempty"` //alternative to the Idempotency-Key header
+}
+
+type ProcessInstanceWithVariables struct {
+	ProcessInstance
+	Variables map[string]Variable `json:"variables,omitempty"`
+}
+
+// BatchStartItem is an entry of the body of the start-batch endpoint
+type BatchStartItem struct {
+	BusinessKey string                 `json:"business_key,omitempty"`
+	Inputs      map[string]interface{} `json:"inputs,omitempty"`
+}
+
+// BatchStartResult is the result of a BatchStartItem with the same index; either Instance or Error is set
+type BatchStartResult struct {
+	BusinessKey string           `json:"business_key,omitempty"`
+	Instance    *ProcessInstance `json:"instance,omitempty"`
+	Error       string           `json:"error,omitempty"`
+	Fields      []FieldError     `json:"fields,omitempty"` //invalid inputs
+}
+
+type StartParameterError struct {
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// IdempotentResponse is the stored response of a start request with idempotency key
+type IdempotentResponse struct {
+	Code        int
+	ContentType string
+	Body        []byte
+}
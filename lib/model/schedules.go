@@ -0,0 +1,46 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+const (
+	StartScheduleStatusOk     = "ok"
+	StartScheduleStatusFailed = "failed"
+)
+
+// StartSchedule starts a process-instance of the deployment at every match of the cron expression.
+// BusinessKey is a go template with the fields .ScheduleId, .DeploymentId and .Time (e.g. `report-{{.Time.Format "2006-01-02"}}`).
+type StartSchedule struct {
+	Id           string                 `json:"id"`
+	DeploymentId string                 `json:"deployment_id"`
+	Cron         string                 `json:"cron"`
+	TimeZone     string                 `json:"time_zone"` //IANA time zone (e.g. Europe/Berlin); default UTC
+	Inputs       map[string]interface{} `json:"inputs,omitempty"`
+	BusinessKey  string                 `json:"business_key,omitempty"`
+	Enabled      bool                   `json:"enabled"`
+
+	NextRun        *time.Time `json:"next_run,omitempty"`
+	LastRun        *time.Time `json:"last_run,omitempty"`
+	LastStatus     string     `json:"last_status,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	LastInstanceId string     `json:"last_instance_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	UserId string `json:"-"`
+}
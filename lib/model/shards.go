@@ -0,0 +1,40 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+const InstanceHandlingDrain = "drain"
+const InstanceHandlingCancel = "cancel"
+
+type RebalanceRequest struct {
+	UserId      string `json:"user_id"`
+	TargetShard string `json:"target_shard"` //optional; defaults to the shard with the fewest users
+
+	//InstanceHandling decides what happens with running instances on the source shard
+	//	"drain": wait up to DrainTimeout for running instances to finish (default)
+	//	"cancel": delete running instances
+	InstanceHandling string `json:"instance_handling"`
+	DrainTimeout     string `json:"drain_timeout"` //optional; duration like "10m", defaults to no waiting
+}
+
+type RebalanceResult struct {
+	UserId             string   `json:"user_id"`
+	SourceShard        string   `json:"source_shard"`
+	TargetShard        string   `json:"target_shard"`
+	MovedDeployments   []string `json:"moved_deployments"`
+	CancelledInstances int      `json:"cancelled_instances"`
+	CleanupErrors      []string `json:"cleanup_errors,omitempty"`
+}
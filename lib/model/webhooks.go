@@ -0,0 +1,63 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook receives signed ProcessInstanceEvents of the user matching all set filters.
+// the secret is only returned on creation.
+type Webhook struct {
+	Id           string    `json:"id"`
+	Url          string    `json:"url"`
+	Secret       string    `json:"secret,omitempty"` //generated if empty on creation; unchanged if empty on update
+	Events       []string  `json:"events"`           //event types (started, finished, deleted, incident); all if empty
+	DeploymentId string    `json:"deployment_id,omitempty"`
+	DefinitionId string    `json:"definition_id,omitempty"`
+	BusinessKey  string    `json:"business_key,omitempty"`
+	Enabled      bool      `json:"enabled"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	UserId string `json:"-"`
+}
+
+// WebhookPayload is the body sent to webhooks
+type WebhookPayload struct {
+	DeliveryId string               `json:"delivery_id"`
+	WebhookId  string               `json:"webhook_id"`
+	Event      ProcessInstanceEvent `json:"event"`
+}
+
+// WebhookDelivery is a queued or finished delivery of an event to a webhook
+type WebhookDelivery struct {
+	Id             string               `json:"id"`
+	WebhookId      string               `json:"webhook_id"`
+	Event          ProcessInstanceEvent `json:"event"`
+	State          string               `json:"state"`
+	Attempts       int                  `json:"attempts"`
+	NextAttempt    *time.Time           `json:"next_attempt,omitempty"`
+	LastStatusCode int                  `json:"last_status_code,omitempty"`
+	LastError      string               `json:"last_error,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+	DeliveredAt    *time.Time           `json:"delivered_at,omitempty"`
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/segmentio/kafka-go"
+)
+
+// Kafka publishes change events as json messages, keyed by model.ChangeEvent.Key to keep the events of a deployment in order
+type Kafka struct {
+	writer *kafka.Writer
+}
+
+// NewKafka creates a publisher writing to config.KafkaChangeTopic of the comma separated brokers in config.KafkaUrl.
+// the writer is closed when ctx is done.
+func NewKafka(ctx context.Context, config configuration.Config) *Kafka {
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(strings.Split(config.KafkaUrl, ",")...),
+		Topic:                  config.KafkaChangeTopic,
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		AllowAutoTopicCreation: true,
+		BatchTimeout:           10 * time.Millisecond,
+	}
+	go func() {
+		<-ctx.Done()
+		err := writer.Close()
+		if err != nil {
+			config.GetLogger().Error("unable to close kafka writer", "error", err)
+		}
+	}()
+	return &Kafka{writer: writer}
+}
+
+func (this *Kafka) Publish(event model.ChangeEvent) error {
+	msg, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return this.writer.WriteMessages(context.Background(), kafka.Message{
+		Key:   []byte(event.Key()),
+		Value: msg,
+		Time:  event.Time,
+	})
+}
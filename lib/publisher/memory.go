@@ -0,0 +1,48 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publisher
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+)
+
+// Memory keeps published change events in memory, e.g. to check them in tests
+type Memory struct {
+	events []model.ChangeEvent
+	mux    sync.Mutex
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (this *Memory) Publish(event model.ChangeEvent) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.events = append(this.events, event)
+	return nil
+}
+
+// Events returns the published events in order of publication
+func (this *Memory) Events() []model.ChangeEvent {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return slices.Clone(this.events)
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedules
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronExpression is a parsed cron expression with the fields minute, hour, day of month, month and day of week.
+// supported are '*', values, ranges (1-5), steps (*/15, 0-30/10), lists (1,15), month and day names (JAN, MON) and the macros @yearly, @monthly, @weekly, @daily and @hourly.
+type CronExpression struct {
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool
+	dowStar bool
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6, "JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12}
+var dayNames = map[string]int{"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6}
+
+func ParseCronExpression(expr string) (result CronExpression, err error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return result, fmt.Errorf("expected 5 fields in cron expression, got %v", len(fields))
+	}
+	if result.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return result, fmt.Errorf("invalid minute: %w", err)
+	}
+	if result.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return result, fmt.Errorf("invalid hour: %w", err)
+	}
+	if result.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return result, fmt.Errorf("invalid day of month: %w", err)
+	}
+	if result.month, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return result, fmt.Errorf("invalid month: %w", err)
+	}
+	if result.dow, err = parseCronField(fields[4], 0, 7, dayNames); err != nil {
+		return result, fmt.Errorf("invalid day of week: %w", err)
+	}
+	if result.dow&(1<<7) != 0 {
+		result.dow |= 1 //7 is sunday
+	}
+	result.domStar = fields[2] == "*" || fields[2] == "?"
+	result.dowStar = fields[4] == "*" || fields[4] == "?"
+	return result, nil
+}
+
+func parseCronField(field string, min int, max int, names map[string]int) (bits uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		rangePart := part
+		hasStep := false
+		if before, after, found := strings.Cut(part, "/"); found {
+			step, err = strconv.Atoi(after)
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q", after)
+			}
+			rangePart = before
+			hasStep = true
+		}
+		var lo, hi int
+		switch {
+		case rangePart == "*" || rangePart == "?":
+			lo, hi = min, max
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			if lo, err = parseCronValue(from, names); err != nil {
+				return 0, err
+			}
+			if hi, err = parseCronValue(to, names); err != nil {
+				return 0, err
+			}
+		default:
+			if lo, err = parseCronValue(rangePart, names); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if hasStep {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q out of range %v-%v", part, min, max)
+		}
+		for value := lo; value <= hi; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, names map[string]int) (int, error) {
+	if i, ok := names[strings.ToUpper(value)]; ok {
+		return i, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	return i, nil
+}
+
+// Next returns the first time after t matching the expression, in the location of t
+func (this CronExpression) Next(t time.Time) (time.Time, error) {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if this.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !this.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if this.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if this.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t, nil
+	}
+	return t, errors.New("cron expression does not match within 5 years")
+}
+
+// dayMatches follows the cron convention: if day of month and day of week are both restricted, one of them has to match
+func (this CronExpression) dayMatches(t time.Time) bool {
+	domMatch := this.dom&(1<<uint(t.Day())) != 0
+	dowMatch := this.dow&(1<<uint(t.Weekday())) != 0
+	if this.domStar || this.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedules
+
+import (
+	"database/sql"
+
+	_ "github.com/lib/pq"
+)
+
+var CreateScheduleTable = `CREATE TABLE IF NOT EXISTS StartSchedule (
+	ID					VARCHAR(255) PRIMARY KEY,
+	VirtualId			VARCHAR(255) NOT NULL,
+	UserId				VARCHAR(255) NOT NULL,
+	Cron				VARCHAR(255) NOT NULL,
+	TimeZone			VARCHAR(255) NOT NULL,
+	Inputs				TEXT NOT NULL,
+	BusinessKey			TEXT NOT NULL DEFAULT '',
+	Enabled				BOOLEAN NOT NULL DEFAULT TRUE,
+	NextRun				TIMESTAMP WITH TIME ZONE,
+	LastRun				TIMESTAMP WITH TIME ZONE,
+	LastStatus			VARCHAR(64) NOT NULL DEFAULT '',
+	LastError			TEXT NOT NULL DEFAULT '',
+	LastInstanceId		VARCHAR(255) NOT NULL DEFAULT '',
+	CreatedAt			TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+	UpdatedAt			TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS schedule_vid_index ON StartSchedule (VirtualId);
+CREATE INDEX IF NOT EXISTS schedule_next_run_index ON StartSchedule (Enabled, NextRun);
+`
+
+func InitDb(pgConn string) (db *sql.DB, err error) {
+	db, err = sql.Open("postgres", pgConn)
+	if err != nil {
+		return
+	}
+	_, err = db.Exec(CreateScheduleTable)
+	return db, err
+}
@@ -0,0 +1,192 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedules
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/google/uuid"
+)
+
+// leaderLockKey is the postgres advisory lock held by the wrapper instance running the scheduler
+const leaderLockKey = 7283610934
+
+func New(pgConn string) (result *Schedules, err error) {
+	result = &Schedules{}
+	result.db, err = InitDb(pgConn)
+	return
+}
+
+// Schedules stores start schedules of deployments
+type Schedules struct {
+	db        *sql.DB
+	leader    *sql.Conn
+	leaderMux sync.Mutex
+}
+
+const scheduleColumns = `ID, VirtualId, UserId, Cron, TimeZone, Inputs, BusinessKey, Enabled, NextRun, LastRun, LastStatus, LastError, LastInstanceId, CreatedAt, UpdatedAt`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSchedule(row scanner) (schedule model.StartSchedule, err error) {
+	var inputs string
+	var nextRun, lastRun sql.NullTime
+	err = row.Scan(&schedule.Id, &schedule.DeploymentId, &schedule.UserId, &schedule.Cron, &schedule.TimeZone, &inputs, &schedule.BusinessKey, &schedule.Enabled,
+		&nextRun, &lastRun, &schedule.LastStatus, &schedule.LastError, &schedule.LastInstanceId, &schedule.CreatedAt, &schedule.UpdatedAt)
+	if err != nil {
+		return schedule, err
+	}
+	if nextRun.Valid {
+		schedule.NextRun = &nextRun.Time
+	}
+	if lastRun.Valid {
+		schedule.LastRun = &lastRun.Time
+	}
+	err = json.Unmarshal([]byte(inputs), &schedule.Inputs)
+	return schedule, err
+}
+
+func scanSchedules(rows *sql.Rows, err error) (result []model.StartSchedule, _ error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result = []model.StartSchedule{}
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, schedule)
+	}
+	return result, rows.Err()
+}
+
+// Create stores a new schedule with a new id
+func (this *Schedules) Create(schedule model.StartSchedule) (result model.StartSchedule, err error) {
+	inputs, err := json.Marshal(schedule.Inputs)
+	if err != nil {
+		return result, err
+	}
+	return scanSchedule(this.db.QueryRow(`INSERT INTO StartSchedule (ID, VirtualId, UserId, Cron, TimeZone, Inputs, BusinessKey, Enabled, NextRun) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING `+scheduleColumns+`;`,
+		uuid.NewString(), schedule.DeploymentId, schedule.UserId, schedule.Cron, schedule.TimeZone, string(inputs), schedule.BusinessKey, schedule.Enabled, schedule.NextRun))
+}
+
+// Update stores the configuration of the schedule; the run status is kept
+func (this *Schedules) Update(schedule model.StartSchedule) (result model.StartSchedule, exists bool, err error) {
+	inputs, err := json.Marshal(schedule.Inputs)
+	if err != nil {
+		return result, false, err
+	}
+	result, err = scanSchedule(this.db.QueryRow(`UPDATE StartSchedule SET Cron = $2, TimeZone = $3, Inputs = $4, BusinessKey = $5, Enabled = $6, NextRun = $7, UpdatedAt = now() WHERE ID = $1 RETURNING `+scheduleColumns+`;`,
+		schedule.Id, schedule.Cron, schedule.TimeZone, string(inputs), schedule.BusinessKey, schedule.Enabled, schedule.NextRun))
+	if errors.Is(err, sql.ErrNoRows) {
+		return result, false, nil
+	}
+	return result, err == nil, err
+}
+
+func (this *Schedules) Get(id string) (result model.StartSchedule, exists bool, err error) {
+	result, err = scanSchedule(this.db.QueryRow(`SELECT `+scheduleColumns+` FROM StartSchedule WHERE ID = $1;`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return result, false, nil
+	}
+	return result, err == nil, err
+}
+
+// List returns the schedules of the deployment ordered by creation
+func (this *Schedules) List(vid string) (result []model.StartSchedule, err error) {
+	return scanSchedules(this.db.Query(`SELECT `+scheduleColumns+` FROM StartSchedule WHERE VirtualId = $1 ORDER BY CreatedAt;`, vid))
+}
+
+// ListDue returns enabled schedules with a NextRun before now
+func (this *Schedules) ListDue(now time.Time) (result []model.StartSchedule, err error) {
+	return scanSchedules(this.db.Query(`SELECT `+scheduleColumns+` FROM StartSchedule WHERE Enabled = TRUE AND NextRun <= $1 ORDER BY NextRun;`, now))
+}
+
+// ClaimRun moves NextRun of the schedule from expected to next; returns false if the run was already claimed or the schedule changed
+func (this *Schedules) ClaimRun(id string, expected time.Time, next *time.Time) (claimed bool, err error) {
+	result, err := this.db.Exec(`UPDATE StartSchedule SET NextRun = $3 WHERE ID = $1 AND NextRun = $2;`, id, expected, next)
+	if err != nil {
+		return false, err
+	}
+	count, err := result.RowsAffected()
+	return count == 1, err
+}
+
+// SetRunResult stores the status of the last run
+func (this *Schedules) SetRunResult(id string, run time.Time, status string, errMsg string, instanceId string) (err error) {
+	_, err = this.db.Exec(`UPDATE StartSchedule SET LastRun = $2, LastStatus = $3, LastError = $4, LastInstanceId = $5 WHERE ID = $1;`, id, run, status, errMsg, instanceId)
+	return err
+}
+
+func (this *Schedules) Remove(id string) (err error) {
+	_, err = this.db.Exec(`DELETE FROM StartSchedule WHERE ID = $1;`, id)
+	return err
+}
+
+// RemoveByVid removes all schedules of the deployment
+func (this *Schedules) RemoveByVid(vid string) (err error) {
+	_, err = this.db.Exec(`DELETE FROM StartSchedule WHERE VirtualId = $1;`, vid)
+	return err
+}
+
+// IsLeader tries to acquire the leader lock; the lock is held by a dedicated connection until it is closed or lost.
+// only one wrapper instance using the same database is leader at any time.
+func (this *Schedules) IsLeader(ctx context.Context) (bool, error) {
+	this.leaderMux.Lock()
+	defer this.leaderMux.Unlock()
+	if this.leader != nil {
+		err := this.leader.PingContext(ctx)
+		if err == nil {
+			return true, nil
+		}
+		_ = this.leader.Close()
+		this.leader = nil
+	}
+	conn, err := this.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	acquired := false
+	err = conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1);`, leaderLockKey).Scan(&acquired)
+	if err != nil || !acquired {
+		_ = conn.Close()
+		return false, err
+	}
+	this.leader = conn
+	return true, nil
+}
+
+// ResignLeader releases the leader lock
+func (this *Schedules) ResignLeader() {
+	this.leaderMux.Lock()
+	defer this.leaderMux.Unlock()
+	if this.leader != nil {
+		_, _ = this.leader.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1);`, leaderLockKey)
+		_ = this.leader.Close()
+		this.leader = nil
+	}
+}
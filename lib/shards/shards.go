@@ -3,6 +3,7 @@ package shards
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/shards/cache"
 	_ "github.com/lib/pq"
@@ -30,6 +31,10 @@ func initDbConnection(conStr string) (db *sql.DB, err error) {
 	if err != nil {
 		return db, err
 	}
+	_, err = db.Exec(SqlCreateShardsMoveTable)
+	if err != nil {
+		return db, err
+	}
 	return db, err
 }
 
@@ -86,6 +91,67 @@ func (this *Shards) SetShardForUser(userId string, shardAddress string) (err err
 	return this.cache.Invalidate(CachePrefix + userId)
 }
 
+// SetUserMoving marks the deployments of the user as moved to another shard until UnsetUserMoving is called or until is reached
+func (this *Shards) SetUserMoving(userId string, until time.Time) (err error) {
+	_, err = this.db.Exec(SqlSetUserMoving, userId, until)
+	return
+}
+
+func (this *Shards) UnsetUserMoving(userId string) (err error) {
+	_, err = this.db.Exec(SqlDeleteUserMoving, userId)
+	return
+}
+
+// IsUserMoving is not cached, so that a move is noticed by all wrapper instances immediately
+func (this *Shards) IsUserMoving(userId string) (moving bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	count := 0
+	err = this.db.QueryRowContext(ctx, SqlCountUserMoving, userId).Scan(&count)
+	return count > 0, err
+}
+
+// LockUserShared holds a shared lock of the user until unlock is called; used by deployments, which may run concurrently
+func (this *Shards) LockUserShared(userId string) (unlock func(), err error) {
+	unlock, _, err = this.lockUser(context.Background(), SqlLockUserShared, SqlUnlockUserShared, "shard-user."+userId)
+	return unlock, err
+}
+
+// LockUserExclusive waits until all shared locks of the user are released (or ctx is done) and holds the lock until unlock is called
+func (this *Shards) LockUserExclusive(ctx context.Context, userId string) (unlock func(), err error) {
+	unlock, _, err = this.lockUser(ctx, SqlLockUserExclusive, SqlUnlockUserExclusive, "shard-user."+userId)
+	return unlock, err
+}
+
+// TryLockUserRebalance returns locked=false if another rebalance of the user holds the lock
+func (this *Shards) TryLockUserRebalance(userId string) (unlock func(), locked bool, err error) {
+	return this.lockUser(context.Background(), SqlTryLockUser, SqlUnlockUserExclusive, "shard-rebalance."+userId)
+}
+
+// lockUser uses a postgres session advisory lock, so the connection is reserved until unlock is called
+func (this *Shards) lockUser(ctx context.Context, lockQuery string, unlockQuery string, key string) (unlock func(), locked bool, err error) {
+	conn, err := this.db.Conn(ctx)
+	if err != nil {
+		return func() {}, false, err
+	}
+	locked = true
+	err = conn.QueryRowContext(ctx, lockQuery, key).Scan(&locked)
+	if err != nil || !locked {
+		conn.Close()
+		return func() {}, false, err
+	}
+	return func() {
+		_, err := conn.ExecContext(context.Background(), unlockQuery, key)
+		if err != nil {
+			//the lock is bound to the session --> discard the connection instead of returning it to the pool
+			_ = conn.Raw(func(driverConn any) error {
+				return driver.ErrBadConn
+			})
+		}
+		conn.Close()
+	}, true, nil
+}
+
 func (this *Shards) EnsureShardForUser(userId string) (shardUrl string, err error) {
 	ctx, _ := context.WithTimeout(context.Background(), 2*time.Second)
 	tx, err := this.db.BeginTx(ctx, nil)
@@ -126,13 +192,25 @@ func (this *Shards) SelectShard() (shardUrl string, err error) {
 	return selectShard(this.db)
 }
 
+// selects shard with the fewest users, ignoring the excluded shards
+func (this *Shards) SelectShardExcluding(excluded ...string) (shardUrl string, err error) {
+	return selectShardExcluding(this.db, excluded)
+}
+
 // selects shard with the fewest users
 func selectShard(tx Tx) (shardUrl string, err error) {
+	return selectShardExcluding(tx, nil)
+}
+
+func selectShardExcluding(tx Tx, excluded []string) (shardUrl string, err error) {
 	min := MaxInt
 	counts, err := getShardUserCount(tx)
 	if err != nil {
 		return shardUrl, err
 	}
+	for _, shard := range excluded {
+		delete(counts, shard)
+	}
 	for shard, userCount := range counts {
 		if min >= userCount {
 			min = userCount
@@ -26,3 +26,24 @@ const SqlShardUserCount = `SELECT COUNT(ShardsMapping.UserId), Shard.Address
 	GROUP BY Shard.Address;`
 
 const SQLListShards = `SELECT Address FROM Shard`
+
+const SqlCreateShardsMoveTable = `CREATE TABLE IF NOT EXISTS ShardsMove (
+	UserId				VARCHAR(255) PRIMARY KEY,
+	Until				TIMESTAMP WITH TIME ZONE NOT NULL
+);`
+
+const SqlSetUserMoving = `INSERT INTO ShardsMove (UserId, Until) VALUES ($1, $2) ON CONFLICT (UserId) DO UPDATE SET Until = $2;`
+
+const SqlDeleteUserMoving = `DELETE FROM ShardsMove WHERE UserId = $1;`
+
+const SqlCountUserMoving = `SELECT COUNT(*) FROM ShardsMove WHERE UserId = $1 AND Until > now();`
+
+const SqlLockUserShared = `SELECT true FROM pg_advisory_lock_shared(hashtext($1));`
+
+const SqlUnlockUserShared = `SELECT pg_advisory_unlock_shared(hashtext($1));`
+
+const SqlLockUserExclusive = `SELECT true FROM pg_advisory_lock(hashtext($1));`
+
+const SqlTryLockUser = `SELECT pg_try_advisory_lock(hashtext($1));`
+
+const SqlUnlockUserExclusive = `SELECT pg_advisory_unlock(hashtext($1));`
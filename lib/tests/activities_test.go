@@ -0,0 +1,107 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+)
+
+func TestActivities(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	config, wrapperUrl, shard, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	instance := model.ProcessInstance{}
+
+	t.Run("deploy process withInput", testDeployProcessWithInput(wrapperClient, "withInput", processWithInput))
+	t.Run("start withInput", testStartProcessWithInputReturnInstance(wrapperUrl, "withInput", map[string]interface{}{"inputTemperature": 30}, &instance))
+
+	t.Run("get activities", func(t *testing.T) {
+		result, err, _ := wrapperClient.GetProcessInstanceActivities(helper.Jwt, instance.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.ProcessInstanceId != instance.Id {
+			t.Errorf("%#v", result)
+		}
+		if len(result.ChildActivityInstances) != 1 || result.ChildActivityInstances[0].ActivityId != "Task_1ol5jfc" {
+			t.Errorf("%#v", result.ChildActivityInstances)
+		}
+	})
+
+	t.Run("get historic activities of running instance", func(t *testing.T) {
+		result, err, _ := wrapperClient.GetHistoricProcessInstanceActivities(helper.Jwt, instance.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result) != 2 || result[0].ActivityId != "StartEvent_1" || result[0].EndTime == "" || result[1].ActivityId != "Task_1ol5jfc" || result[1].EndTime != "" {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("finish instance", testFetchAndComplete(shard))
+
+	t.Run("get activities of finished instance", func(t *testing.T) {
+		_, err, code := wrapperClient.GetProcessInstanceActivities(helper.Jwt, instance.Id)
+		if err == nil || code != 404 {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("get historic activities of finished instance", func(t *testing.T) {
+		result, err, _ := wrapperClient.GetHistoricProcessInstanceActivities(helper.Jwt, instance.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result) != 3 || result[2].EndTime == "" {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("get historic activities of unknown instance", func(t *testing.T) {
+		_, err, code := wrapperClient.GetHistoricProcessInstanceActivities(helper.Jwt, "unknown")
+		if err == nil || code != 401 {
+			t.Error(err, code)
+		}
+	})
+}
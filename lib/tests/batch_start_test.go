@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+)
+
+func TestStartDeploymentBatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	config.StartBatchMaxSize = 30
+	config.StartBatchConcurrency = 4
+
+	config, wrapperUrl, _, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	t.Run("deploy", testDeployProcessWithInput(wrapperClient, "withForm", processWithForm))
+
+	t.Run("start batch", func(t *testing.T) {
+		items := []client.BatchStartItem{}
+		for i := 0; i < 20; i++ {
+			items = append(items, client.BatchStartItem{
+				BusinessKey: "device-" + strconv.Itoa(i),
+				Inputs:      map[string]interface{}{"inputTemperature": 20 + i},
+			})
+		}
+		items = append(items, client.BatchStartItem{BusinessKey: "invalid", Inputs: map[string]interface{}{"inputTemperature": 10}})
+
+		results, err, _ := wrapperClient.StartDeploymentBatch(helper.Jwt, "withForm", items)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(results) != len(items) {
+			t.Error(len(results))
+			return
+		}
+		ids := map[string]bool{}
+		for i, result := range results[:20] {
+			if result.Error != "" || result.Instance == nil || result.BusinessKey != items[i].BusinessKey || result.Instance.BusinessKey != items[i].BusinessKey {
+				t.Errorf("%#v", result)
+				continue
+			}
+			ids[result.Instance.Id] = true
+		}
+		if len(ids) != 20 {
+			t.Error(len(ids))
+		}
+		if invalid := results[20]; invalid.Instance != nil || invalid.Error == "" || len(invalid.Fields) != 1 {
+			t.Errorf("%#v", invalid)
+		}
+	})
+
+	t.Run("batch too large", func(t *testing.T) {
+		_, err, code := wrapperClient.StartDeploymentBatch(helper.Jwt, "withForm", make([]client.BatchStartItem, 31))
+		if err == nil || code != http.StatusBadRequest {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("unknown deployment", func(t *testing.T) {
+		_, err, code := wrapperClient.StartDeploymentBatch(helper.Jwt, "unknown", []client.BatchStartItem{{}})
+		if err == nil || code != http.StatusUnauthorized {
+			t.Error(err, code)
+		}
+	})
+}
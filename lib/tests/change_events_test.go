@@ -0,0 +1,105 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/publisher"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/resources"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+)
+
+func TestChangeEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	changes := publisher.NewMemory()
+	config, wrapperUrl, _, err := server.CreateTestEnvWithPublisher(ctx, &wg, config, changes)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+	userId := helper.JwtPayload.GetUserId()
+
+	t.Run("deploy", testDeployProcessWithInput(wrapperClient, "changes", resources.LongProcess))
+
+	instance, err, _ := wrapperClient.StartDeployment(helper.Jwt, "changes", client.StartOptions{BusinessKey: "changes-long"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err, _ = wrapperClient.DeleteProcessInstancesByBusinessKey(helper.Jwt, "changes-long")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err, _ = wrapperClient.DeleteDeployment(client.InternalAdminToken, userId, "changes")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := []model.ChangeEvent{
+		{Type: model.ChangeEventDeploymentCreated, UserId: userId, DeploymentId: "changes", DeploymentName: "processWithInput"},
+		{Type: model.ChangeEventInstanceStarted, UserId: userId, DeploymentId: "changes", ProcessInstanceId: instance.Id, BusinessKey: "changes-long"},
+		{Type: model.ChangeEventInstanceDeleted, UserId: userId, DeploymentId: "changes", ProcessInstanceId: instance.Id, BusinessKey: "changes-long"},
+		{Type: model.ChangeEventDeploymentDeleted, UserId: userId, DeploymentId: "changes"},
+	}
+	var actual []model.ChangeEvent
+	for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(200 * time.Millisecond) {
+		actual = changes.Events()
+		if len(actual) >= len(expected) {
+			break
+		}
+	}
+	if len(actual) != len(expected) {
+		t.Errorf("%#v", actual)
+		return
+	}
+	for i, event := range actual {
+		if event.Time.IsZero() {
+			t.Errorf("%#v", event)
+		}
+		event.Time = time.Time{}
+		event.ProcessDefinitionId = ""
+		if event != expected[i] {
+			t.Errorf("\n%#v\n%#v", event, expected[i])
+		}
+	}
+	if !slices.ContainsFunc(actual, func(event model.ChangeEvent) bool { return event.ProcessDefinitionId != "" }) {
+		t.Error("missing process definition id in instance events")
+	}
+}
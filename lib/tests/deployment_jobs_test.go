@@ -0,0 +1,291 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/jobs"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+)
+
+func TestDeploymentJobRecovery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	config.DeploymentJobStaleTimeout = "1s"
+
+	config, wrapperUrl, shard, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	j, err := jobs.New(config.WrapperDb)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	userId := helper.JwtPayload.GetUserId()
+	depl := model.DeploymentMessage{
+		Deployment: model.Deployment{
+			Id:   "interrupted",
+			Name: "interrupted",
+			Diagram: model.Diagram{
+				XmlDeployed: processWithInput,
+				Svg:         helper.SvgExample,
+			},
+		},
+		UserId: userId,
+	}
+
+	interrupted := model.DeploymentJob{}
+	broken := model.DeploymentJob{}
+	unrecordedDeploymentId := ""
+
+	t.Run("create interrupted job", func(t *testing.T) {
+		interrupted, err = j.Create(depl, model.DeploymentJobStateRunning, "")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		//deployed, but the engine deployment id was not recorded
+		result, err := deployProcess(shard, depl.Name, processWithInput, helper.SvgExample, userId, "")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		unrecordedDeploymentId, _ = result["id"].(string)
+		interrupted.Step = model.DeploymentJobStepCleaned
+		err = j.Update(&interrupted)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("create broken job", func(t *testing.T) {
+		broken, err = j.Create(depl, model.DeploymentJobStateRunning, "")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		broken.Step = "unknown"
+		err = j.Update(&broken)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	time.Sleep(5 * time.Second)
+
+	t.Run("check resumed job", func(t *testing.T) {
+		job, err, _ := wrapperClient.GetDeploymentJob(helper.Jwt, interrupted.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if job.State != model.DeploymentJobStateDone || job.Step != model.DeploymentJobStepDone || job.DeploymentId != "interrupted" {
+			t.Errorf("%#v", job)
+		}
+	})
+
+	//the deployment can not be attributed to the interrupted job and may belong to a concurrently running job; it is left to the cleanup command
+	t.Run("check unrecorded deployment kept", testCheckCamundaProcess(shard, unrecordedDeploymentId, true))
+
+	t.Run("check deployment", func(t *testing.T) {
+		list, err, _ := wrapperClient.ListDeployments(helper.Jwt, client.DeploymentListOptions{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(list) != 1 || list[0].Id != "interrupted" {
+			t.Errorf("%#v", list)
+		}
+	})
+
+	t.Run("check failed job", func(t *testing.T) {
+		job, err, _ := wrapperClient.GetDeploymentJob(helper.Jwt, broken.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if job.State != model.DeploymentJobStateFailed || job.Error == "" {
+			t.Errorf("%#v", job)
+		}
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		_, err, code := wrapperClient.GetDeploymentJob(helper.Jwt, "unknown")
+		if err == nil || code != 404 {
+			t.Error(err, code)
+		}
+	})
+}
+
+func TestAsyncDeployment(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	config, wrapperUrl, _, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	callbacks := make(chan model.DeploymentJob, 10)
+	callbackServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		job := model.DeploymentJob{}
+		err := json.NewDecoder(request.Body).Decode(&job)
+		if err != nil {
+			t.Error(err)
+		}
+		callbacks <- job
+	}))
+	defer callbackServer.Close()
+
+	depl := model.DeploymentMessage{
+		Deployment: model.Deployment{
+			Id:   "async",
+			Name: "async",
+			Diagram: model.Diagram{
+				XmlDeployed: processWithInput,
+				Svg:         helper.SvgExample,
+			},
+		},
+		UserId: helper.JwtPayload.GetUserId(),
+	}
+
+	job := model.DeploymentJob{}
+
+	t.Run("invalid deployment", func(t *testing.T) {
+		invalid := depl
+		invalid.Diagram.Svg = ""
+		_, err, code := wrapperClient.DeployAsync(client.InternalAdminToken, invalid, "")
+		if err == nil || code != 400 {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("invalid callback", func(t *testing.T) {
+		_, err, code := wrapperClient.DeployAsync(client.InternalAdminToken, depl, "foo")
+		if err == nil || code != 400 {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("deploy async", func(t *testing.T) {
+		var code int
+		job, err, code = wrapperClient.DeployAsync(client.InternalAdminToken, depl, callbackServer.URL)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusAccepted || job.Id == "" || job.State != model.DeploymentJobStateQueued {
+			t.Errorf("%v %#v", code, job)
+		}
+	})
+
+	t.Run("callback", func(t *testing.T) {
+		select {
+		case result := <-callbacks:
+			if result.Id != job.Id || result.State != model.DeploymentJobStateDone {
+				t.Errorf("%#v", result)
+			}
+		case <-time.After(time.Minute):
+			t.Error("missing callback")
+		}
+	})
+
+	t.Run("check job", func(t *testing.T) {
+		result, err, _ := wrapperClient.GetDeploymentJob(helper.Jwt, job.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.State != model.DeploymentJobStateDone || result.Step != model.DeploymentJobStepDone {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("start deployment", func(t *testing.T) {
+		_, err, _ := wrapperClient.StartDeployment(helper.Jwt, "async", client.StartOptions{Inputs: map[string]interface{}{"inputTemperature": 30}})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("reject deployment of vid with queued job", func(t *testing.T) {
+		j, err := jobs.New(config.WrapperDb)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		queued, err := j.Create(depl, model.DeploymentJobStateQueued, "")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err, code := wrapperClient.DeployAsync(client.InternalAdminToken, depl, "")
+		if err == nil || code != http.StatusConflict {
+			t.Error(err, code)
+		}
+		err, code = wrapperClient.Deploy(client.InternalAdminToken, depl)
+		if err == nil || code != http.StatusConflict {
+			t.Error(err, code)
+		}
+		queued.State = model.DeploymentJobStateFailed
+		err = j.Update(&queued)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err, code = wrapperClient.DeployAsync(client.InternalAdminToken, depl, "")
+		if err != nil || code != http.StatusAccepted {
+			t.Error(err, code)
+		}
+	})
+}
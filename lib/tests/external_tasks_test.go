@@ -0,0 +1,197 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/api"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+)
+
+func TestExternalTasks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	config.ExternalTaskMaxPollTimeout = "2s"
+
+	config, wrapperUrl, _, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	fetch := func(timeout int64) ([]client.LockedExternalTask, error) {
+		tasks, err, _ := wrapperClient.FetchAndLockExternalTasks(helper.Jwt, client.ExternalTaskFetchRequest{
+			WorkerId:             "test-worker",
+			MaxTasks:             10,
+			AsyncResponseTimeout: timeout,
+			Topics:               []client.ExternalTaskTopic{{TopicName: "optimistic", LockDuration: 60000}},
+		})
+		return tasks, err
+	}
+
+	t.Run("long polling without tasks", func(t *testing.T) {
+		start := time.Now()
+		tasks, err := fetch(60000)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(tasks) != 0 {
+			t.Errorf("%#v", tasks)
+		}
+		if duration := time.Since(start); duration < time.Second || duration > 10*time.Second {
+			t.Error(duration)
+		}
+	})
+
+	t.Run("invalid fetch request", func(t *testing.T) {
+		_, err, code := wrapperClient.FetchAndLockExternalTasks(helper.Jwt, client.ExternalTaskFetchRequest{MaxTasks: 10})
+		if err == nil || code != http.StatusBadRequest {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("deploy", testDeployProcessWithInput(wrapperClient, "external", processWithInput))
+
+	instance, err, _ := wrapperClient.StartDeployment(helper.Jwt, "external", client.StartOptions{BusinessKey: "external"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var task client.LockedExternalTask
+	t.Run("fetch and lock", func(t *testing.T) {
+		tasks, err := fetch(0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(tasks) != 1 || tasks[0].ProcessInstanceId != instance.Id || tasks[0].TenantId != helper.JwtPayload.GetUserId() || tasks[0].WorkerId != "test-worker" {
+			t.Errorf("%#v", tasks)
+			return
+		}
+		task = tasks[0]
+	})
+
+	t.Run("locked task is not fetched again", func(t *testing.T) {
+		tasks, err := fetch(0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(tasks) != 0 {
+			t.Errorf("%#v", tasks)
+		}
+	})
+
+	t.Run("extend lock", func(t *testing.T) {
+		err, _ := wrapperClient.ExtendExternalTaskLock(helper.Jwt, task.Id, client.ExternalTaskExtendLockRequest{WorkerId: "test-worker", NewDuration: 60000})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("extend lock of other worker", func(t *testing.T) {
+		err, _ := wrapperClient.ExtendExternalTaskLock(helper.Jwt, task.Id, client.ExternalTaskExtendLockRequest{WorkerId: "other-worker", NewDuration: 60000})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("failure with retry", func(t *testing.T) {
+		err, _ := wrapperClient.HandleExternalTaskFailure(helper.Jwt, task.Id, client.ExternalTaskFailureRequest{WorkerId: "test-worker", ErrorMessage: "test failure", Retries: 1})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		tasks, err := fetch(0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(tasks) != 1 || tasks[0].Id != task.Id || tasks[0].ErrorMessage != "test failure" {
+			t.Errorf("%#v", tasks)
+		}
+	})
+
+	t.Run("complete unknown task", func(t *testing.T) {
+		err, code := wrapperClient.CompleteExternalTask(helper.Jwt, "unknown", client.ExternalTaskCompleteRequest{WorkerId: "test-worker"})
+		if err == nil || code != http.StatusNotFound {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		err, _ := wrapperClient.CompleteExternalTask(helper.Jwt, task.Id, client.ExternalTaskCompleteRequest{WorkerId: "test-worker"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		instances, err, _ := wrapperClient.ListProcessInstances(helper.Jwt, client.InstanceListOptions{BusinessKey: "external"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(instances) != 0 {
+			t.Errorf("%#v", instances)
+		}
+	})
+}
+
+func TestExternalTaskMaxPollTimeoutCheck(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	config.HttpServerTimeout = "30s"
+	config.HttpClientTimeout = "10s"
+	config.ExternalTaskMaxPollTimeout = "20s"
+
+	err = api.Start(ctx, config, nil, nil, nil)
+	if err == nil {
+		t.Error("expected error for poll timeout above http_client_timeout")
+	}
+
+	config.HttpClientTimeout = "30s"
+	config.ExternalTaskMaxPollTimeout = "30s"
+	err = api.Start(ctx, config, nil, nil, nil)
+	if err == nil {
+		t.Error("expected error for poll timeout equal to http_server_timeout")
+	}
+}
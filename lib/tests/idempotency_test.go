@@ -0,0 +1,153 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/controller"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/idempotency"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+)
+
+func TestIdempotentStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	config, wrapperUrl, _, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	t.Run("deploy", testDeployProcessWithInput(wrapperClient, "withForm", processWithForm))
+
+	start := func(key string, temperature int) (client.ProcessInstance, error, int) {
+		return wrapperClient.StartDeployment(helper.Jwt, "withForm", client.StartOptions{
+			Inputs:         map[string]interface{}{"inputTemperature": temperature},
+			IdempotencyKey: key,
+		})
+	}
+
+	first, err, _ := start("key1", 30)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("retry returns first instance", func(t *testing.T) {
+		retry, err, _ := start("key1", 30)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if retry.Id != first.Id {
+			t.Error(retry.Id, first.Id)
+		}
+	})
+
+	t.Run("other key starts new instance", func(t *testing.T) {
+		other, err, _ := start("key2", 30)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if other.Id == first.Id {
+			t.Error(other.Id)
+		}
+	})
+
+	t.Run("key with different request", func(t *testing.T) {
+		_, err, code := start("key1", 31)
+		if err == nil || code != http.StatusUnprocessableEntity {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("failed start is not stored", func(t *testing.T) {
+		_, err, code := start("key3", 10)
+		if err == nil || code != http.StatusBadRequest {
+			t.Error(err, code)
+			return
+		}
+		_, err, code = start("key3", 10)
+		if err == nil || code != http.StatusBadRequest {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("abandoned pending key", func(t *testing.T) {
+		idem, err := idempotency.New(config.WrapperDb)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		userId := helper.JwtPayload.GetUserId()
+		_, reserved, err := idem.Reserve(userId, "abandoned", "hash", time.Hour, controller.IdempotencyPendingLease)
+		if err != nil || !reserved {
+			t.Error(err, reserved)
+			return
+		}
+		existing, reserved, err := idem.Reserve(userId, "abandoned", "hash", time.Hour, controller.IdempotencyPendingLease)
+		if err != nil || reserved || existing.State != idempotency.StatePending {
+			t.Error(err, reserved, existing)
+			return
+		}
+		time.Sleep(time.Second)
+		_, reserved, err = idem.Reserve(userId, "abandoned", "hash", time.Hour, time.Millisecond)
+		if err != nil || !reserved {
+			t.Error(err, reserved)
+		}
+	})
+
+	t.Run("key in body", func(t *testing.T) {
+		request := client.StartRequest{
+			Variables:      map[string]client.Variable{"inputTemperature": {Value: 30}},
+			IdempotencyKey: "body-key",
+		}
+		a, err, _ := wrapperClient.StartDeploymentWithRequest(helper.Jwt, "withForm", request)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		b, err, _ := wrapperClient.StartDeploymentWithRequest(helper.Jwt, "withForm", request)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if a.Id == "" || a.Id != b.Id {
+			t.Error(a.Id, b.Id)
+		}
+	})
+}
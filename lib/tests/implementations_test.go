@@ -0,0 +1,213 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/controller"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+)
+
+const implementationTestProcess = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" id="Definitions_1">
+  <bpmn:process id="test" isExecutable="true">
+    <bpmn:extensionElements>
+      <camunda:executionListener class="org.example.Listener" event="start" />
+      <camunda:executionListener camunda:class="org.example.Listener" event="end" />
+    </bpmn:extensionElements>
+    <bpmn:startEvent id="StartEvent_1" />
+    <bpmn:sequenceFlow id="Flow_1" sourceRef="StartEvent_1" targetRef="Task_1" />
+    <bpmn:serviceTask id="Task_1" camunda:class="org.example.allowed.Delegate" />
+    <bpmn:sequenceFlow id="Flow_2" sourceRef="Task_1" targetRef="Task_2" />
+    <bpmn:serviceTask id="Task_2" camunda:delegateExpression="${runtime}">
+      <bpmn:extensionElements>
+        <camunda:taskListener camunda:expression="${foo.bar()}" event="create" />
+      </bpmn:extensionElements>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="Flow_3" sourceRef="Task_2" targetRef="Task_3" />
+    <bpmn:serviceTask id="Task_3">
+      <bpmn:extensionElements>
+        <camunda:connector>
+          <camunda:connectorId>http-connector</camunda:connectorId>
+        </camunda:connector>
+      </bpmn:extensionElements>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="Flow_4" sourceRef="Task_3" targetRef="Task_4" />
+    <bpmn:serviceTask id="Task_4" camunda:type="external" camunda:topic="test" />
+  </bpmn:process>
+</bpmn:definitions>`
+
+func TestCheckImplementations(t *testing.T) {
+	allowList := controller.ImplementationAllowList{
+		Classes:    []string{"org.example.allowed.*"},
+		Connectors: []string{"mail-connector"},
+	}
+
+	t.Run("reject", func(t *testing.T) {
+		_, stripped, rejected, err := controller.CheckImplementations(implementationTestProcess, allowList, false)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(stripped) != 0 {
+			t.Errorf("%#v", stripped)
+		}
+		if ids := implementationMessageIds(rejected); ids != "Task_2,Task_2,Task_3,test,test" {
+			t.Errorf("%v %#v", ids, rejected)
+		}
+	})
+
+	t.Run("strip", func(t *testing.T) {
+		result, stripped, rejected, err := controller.CheckImplementations(implementationTestProcess, allowList, true)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if ids := implementationMessageIds(stripped); ids != "Task_2,test,test" {
+			t.Errorf("%v %#v", ids, stripped)
+		}
+		if ids := implementationMessageIds(rejected); ids != "Task_2,Task_3" {
+			t.Errorf("%v %#v", ids, rejected)
+		}
+		if strings.Contains(result, "org.example.Listener") || strings.Contains(result, "camunda:taskListener") {
+			t.Error(result)
+		}
+		if !strings.Contains(result, `camunda:class="org.example.allowed.Delegate"`) || !strings.Contains(result, `camunda:topic="test"`) {
+			t.Error(result)
+		}
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		allowed := controller.ImplementationAllowList{
+			Classes:             []string{"org.example.Listener", "org.example.allowed.Delegate"},
+			DelegateExpressions: []string{"${runtime}"},
+			Expressions:         []string{"${foo.*"},
+			Connectors:          []string{"http-connector"},
+		}
+		_, stripped, rejected, err := controller.CheckImplementations(implementationTestProcess, allowed, true)
+		if err != nil || len(stripped) != 0 || len(rejected) != 0 {
+			t.Error(err, stripped, rejected)
+		}
+	})
+}
+
+const implementationTestProcessWithOtherPrefix = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:x="http://camunda.org/schema/1.0/bpmn" id="Definitions_1">
+  <bpmn:process id="test" isExecutable="true">
+    <bpmn:extensionElements>
+      <x:executionListener class="org.example.Listener" event="start" />
+    </bpmn:extensionElements>
+    <bpmn:startEvent id="StartEvent_1" />
+    <bpmn:sequenceFlow id="Flow_1" sourceRef="StartEvent_1" targetRef="Task_1" />
+    <bpmn:serviceTask id="Task_1" x:class="org.example.Delegate" />
+    <bpmn:sequenceFlow id="Flow_2" sourceRef="Task_1" targetRef="Task_2" />
+    <bpmn:serviceTask id="Task_2" x:delegateExpression="${runtime}" />
+    <bpmn:sequenceFlow id="Flow_3" sourceRef="Task_2" targetRef="Task_3" />
+    <bpmn:serviceTask id="Task_3">
+      <bpmn:extensionElements>
+        <x:connector>
+          <x:connectorId>http-connector</x:connectorId>
+        </x:connector>
+      </bpmn:extensionElements>
+    </bpmn:serviceTask>
+  </bpmn:process>
+</bpmn:definitions>`
+
+func TestCheckImplementationsWithOtherPrefix(t *testing.T) {
+	t.Run("reject", func(t *testing.T) {
+		_, stripped, rejected, err := controller.CheckImplementations(implementationTestProcessWithOtherPrefix, controller.ImplementationAllowList{}, false)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(stripped) != 0 {
+			t.Errorf("%#v", stripped)
+		}
+		if ids := implementationMessageIds(rejected); ids != "Task_1,Task_2,Task_3,test" {
+			t.Errorf("%v %#v", ids, rejected)
+		}
+	})
+
+	t.Run("strip", func(t *testing.T) {
+		result, stripped, rejected, err := controller.CheckImplementations(implementationTestProcessWithOtherPrefix, controller.ImplementationAllowList{}, true)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if ids := implementationMessageIds(stripped); ids != "test" {
+			t.Errorf("%v %#v", ids, stripped)
+		}
+		if ids := implementationMessageIds(rejected); ids != "Task_1,Task_2,Task_3" {
+			t.Errorf("%v %#v", ids, rejected)
+		}
+		if strings.Contains(result, "org.example.Listener") {
+			t.Error(result)
+		}
+	})
+}
+
+func TestValidateImplementationCheck(t *testing.T) {
+	for _, check := range []string{"", controller.ImplementationCheckOff, controller.ImplementationCheckStrip, controller.ImplementationCheckReject} {
+		if err := controller.ValidateImplementationCheck(check); err != nil {
+			t.Error(check, err)
+		}
+	}
+	if err := controller.ValidateImplementationCheck("unknown"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestValidateImplementations(t *testing.T) {
+	validate := func(check string) model.DeploymentValidationResult {
+		ctrl := controller.New(configuration.Config{
+			ImplementationCheck:    check,
+			AllowedDelegateClasses: []string{"org.example.*"},
+			AllowedConnectors:      []string{"http-connector"},
+		}, nil, nil, nil)
+		return ctrl.ValidateDeployment("user", model.Deployment{
+			Id:      "test",
+			Name:    "test",
+			Diagram: model.Diagram{XmlDeployed: implementationTestProcess, Svg: helper.SvgExample},
+		})
+	}
+	if result := validate(controller.ImplementationCheckOff); !result.Valid {
+		t.Errorf("%#v", result)
+	}
+	if result := validate(controller.ImplementationCheckReject); result.Valid || len(result.Errors) != 2 || len(result.Warnings) != 0 {
+		t.Errorf("%#v", result)
+	}
+	if result := validate(controller.ImplementationCheckStrip); result.Valid || len(result.Errors) != 1 || len(result.Warnings) != 1 {
+		t.Errorf("%#v", result)
+	}
+	if result := validate("unknown"); result.Valid {
+		t.Errorf("%#v", result)
+	}
+}
+
+func implementationMessageIds(messages []model.ValidationMessage) string {
+	ids := []string{}
+	for _, msg := range messages {
+		ids = append(ids, msg.ElementId)
+	}
+	slices.Sort(ids)
+	return strings.Join(ids, ",")
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+	incidentclient "github.com/SENERGY-Platform/process-incident-api/lib/client"
+)
+
+func TestDeploymentIncidentHandling(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	config, wrapperUrl, _, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	t.Run("deploy", testDeployProcessWithInput(wrapperClient, "handling", processWithInput))
+
+	t.Run("deploy without incident handling", func(t *testing.T) {
+		err, _ := wrapperClient.Deploy(client.InternalAdminToken, client.DeploymentMessage{
+			Deployment: client.Deployment{
+				Id:      "unhandled",
+				Name:    "unhandled",
+				Diagram: client.Diagram{XmlDeployed: processWithInput, Svg: helper.SvgExample},
+			},
+			UserId: helper.JwtPayload.GetUserId(),
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("get deployed incident handling", func(t *testing.T) {
+		handling, err, _ := wrapperClient.GetDeploymentIncidentHandling(helper.Jwt, "handling")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if handling.Restart || handling.Notify {
+			t.Errorf("%#v", handling)
+		}
+	})
+
+	t.Run("get missing incident handling", func(t *testing.T) {
+		_, err, code := wrapperClient.GetDeploymentIncidentHandling(helper.Jwt, "unhandled")
+		if err == nil || code != http.StatusNotFound {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("get incident handling registered before it was stored", func(t *testing.T) {
+		deployments, err, _ := wrapperClient.ListDeployments(helper.Jwt, client.DeploymentListOptions{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		definitionId := ""
+		for _, deployment := range deployments {
+			if deployment.Id == "unhandled" {
+				definitionId = deployment.DefinitionId
+			}
+		}
+		if definitionId == "" {
+			t.Errorf("%#v", deployments)
+			return
+		}
+		//simulates a handler registered by an older wrapper version, which did not store the incident handling
+		body, err := json.Marshal(incidentclient.OnIncident{ProcessDefinitionId: definitionId, Restart: true})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		resp, err := http.Post(config.IncidentApiUrl+"/on-incident-handler", "application/json", bytes.NewBuffer(body))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		resp.Body.Close()
+		handling, err, _ := wrapperClient.GetDeploymentIncidentHandling(helper.Jwt, "unhandled")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !handling.Restart || handling.Notify {
+			t.Errorf("%#v", handling)
+		}
+	})
+
+	instance, err, _ := wrapperClient.StartDeployment(helper.Jwt, "handling", client.StartOptions{BusinessKey: "handling"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("set incident handling", func(t *testing.T) {
+		err, _ := wrapperClient.SetDeploymentIncidentHandling(helper.Jwt, "handling", client.IncidentHandling{Restart: true, Notify: true})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		handling, err, _ := wrapperClient.GetDeploymentIncidentHandling(helper.Jwt, "handling")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !handling.Restart || !handling.Notify {
+			t.Errorf("%#v", handling)
+		}
+	})
+
+	t.Run("running instance kept", func(t *testing.T) {
+		instances, err, _ := wrapperClient.ListProcessInstances(helper.Jwt, client.InstanceListOptions{BusinessKey: "handling"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(instances) != 1 || instances[0].Id != instance.Id {
+			t.Errorf("%#v", instances)
+		}
+	})
+
+	t.Run("set incident handling of unknown deployment", func(t *testing.T) {
+		err, code := wrapperClient.SetDeploymentIncidentHandling(helper.Jwt, "unknown", client.IncidentHandling{Restart: true})
+		if err == nil || code != http.StatusUnauthorized {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("delete deployment", func(t *testing.T) {
+		err, _ := wrapperClient.DeleteDeployment(client.InternalAdminToken, helper.JwtPayload.GetUserId(), "handling")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err, code := wrapperClient.GetDeploymentIncidentHandling(helper.Jwt, "handling")
+		if err == nil || code != http.StatusUnauthorized {
+			t.Error(err, code)
+		}
+	})
+}
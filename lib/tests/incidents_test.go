@@ -0,0 +1,209 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+)
+
+func TestIncidents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	config, wrapperUrl, shard, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	t.Run("deploy", testDeployProcessWithInput(wrapperClient, "incidents", processWithInput))
+	t.Run("deploy other", testDeployProcessWithInput(wrapperClient, "other-incidents", processWithInput))
+
+	instance, err, _ := wrapperClient.StartDeployment(helper.Jwt, "incidents", client.StartOptions{BusinessKey: "incidents"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	otherInstance, err, _ := wrapperClient.StartDeployment(helper.Jwt, "other-incidents", client.StartOptions{BusinessKey: "other-incidents"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("fail external tasks", func(t *testing.T) {
+		tasks, err := fetchTestTask(shard)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		failed := 0
+		for _, task := range tasks {
+			if task.ProcessInstanceId == instance.Id || task.ProcessInstanceId == otherInstance.Id {
+				err = failTestTask(shard, task.Id, "test failure")
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				failed++
+			}
+		}
+		if failed != 2 {
+			t.Error("missing tasks", tasks)
+		}
+	})
+
+	var incident model.Incident
+	t.Run("list incidents", func(t *testing.T) {
+		incidents, err, _ := wrapperClient.ListIncidents(helper.Jwt, url.Values{"deployment_id": {"incidents"}})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(incidents) != 1 {
+			t.Errorf("%#v", incidents)
+			return
+		}
+		incident = incidents[0]
+		if incident.ProcessInstanceId != instance.Id || incident.IncidentType != model.IncidentTypeFailedExternalTask || incident.IncidentMessage != "test failure" {
+			t.Errorf("%#v", incident)
+		}
+	})
+
+	t.Run("list incidents of other deployment", func(t *testing.T) {
+		incidents, err, _ := wrapperClient.ListIncidents(helper.Jwt, url.Values{"deployment_id": {"other-incidents"}})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(incidents) != 1 || incidents[0].ProcessInstanceId != otherInstance.Id {
+			t.Errorf("%#v", incidents)
+		}
+	})
+
+	t.Run("list all incidents", func(t *testing.T) {
+		incidents, err, _ := wrapperClient.ListIncidents(helper.Jwt, url.Values{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(incidents) != 2 {
+			t.Errorf("%#v", incidents)
+		}
+	})
+
+	t.Run("list incidents of unknown deployment", func(t *testing.T) {
+		_, err, code := wrapperClient.ListIncidents(helper.Jwt, url.Values{"deployment_id": {"unknown"}})
+		if err == nil || code != http.StatusBadRequest {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("get process-instance incidents", func(t *testing.T) {
+		incidents, err, _ := wrapperClient.GetProcessInstanceIncidents(helper.Jwt, instance.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(incidents) != 1 || incidents[0].Id != incident.Id {
+			t.Errorf("%#v", incidents)
+		}
+	})
+
+	t.Run("resolve unknown incident", func(t *testing.T) {
+		err, code := wrapperClient.ResolveIncident(helper.Jwt, "unknown")
+		if err == nil || code != http.StatusNotFound {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("resolve incident", func(t *testing.T) {
+		err, _ := wrapperClient.ResolveIncident(helper.Jwt, incident.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		incidents, err, _ := wrapperClient.GetProcessInstanceIncidents(helper.Jwt, instance.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(incidents) != 0 {
+			t.Errorf("%#v", incidents)
+		}
+		tasks, err := fetchTestTask(shard)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		for _, task := range tasks {
+			if task.ProcessInstanceId == instance.Id {
+				if task.Retries != 1 {
+					t.Errorf("%#v", task)
+				}
+				return
+			}
+		}
+		t.Error("missing retried task", tasks)
+	})
+}
+
+func failTestTask(shard string, taskId string, message string) error {
+	b, err := json.Marshal(map[string]interface{}{
+		"workerId":     "test",
+		"errorMessage": message,
+		"retries":      0,
+		"retryTimeout": 0,
+	})
+	if err != nil {
+		return err
+	}
+	client := http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Post(shard+"/engine-rest/external-task/"+taskId+"/failure", "application/json", bytes.NewBuffer(b))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return errors.New(resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/events"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/resources"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+)
+
+func TestEventsSubscriptions(t *testing.T) {
+	e := events.New()
+	user1, cancel1 := e.Subscribe("user1", 1)
+	user2, cancel2 := e.Subscribe("user2", 10)
+	defer cancel2()
+	listened := []string{}
+	e.Listen(func(event model.ProcessInstanceEvent) {
+		listened = append(listened, event.ProcessInstanceId)
+	})
+
+	all, tenants := e.Tenants()
+	if !all || !slices.Equal(tenants, []string{"user1", "user2"}) {
+		t.Error(all, tenants)
+	}
+
+	e.Publish(model.ProcessInstanceEvent{UserId: "user1", ProcessInstanceId: "a"})
+	e.Publish(model.ProcessInstanceEvent{UserId: "user1", ProcessInstanceId: "b"}) //dropped: buffer of user1 is full
+	e.Publish(model.ProcessInstanceEvent{UserId: "user2", ProcessInstanceId: "c"})
+	e.Publish(model.ProcessInstanceEvent{UserId: "user3", ProcessInstanceId: "d"})
+
+	if event := <-user1; event.ProcessInstanceId != "a" {
+		t.Error(event)
+	}
+	if event := <-user2; event.ProcessInstanceId != "c" {
+		t.Error(event)
+	}
+	if !slices.Equal(listened, []string{"a", "b", "c", "d"}) {
+		t.Error(listened)
+	}
+
+	cancel1()
+	cancel1()
+	if _, ok := <-user1; ok {
+		t.Error("expected closed channel")
+	}
+	_, tenants = e.Tenants()
+	if !slices.Equal(tenants, []string{"user2"}) {
+		t.Error(tenants)
+	}
+
+	e.Close()
+	if _, ok := <-user2; ok {
+		t.Error("expected closed channel")
+	}
+	closed, cancel3 := e.Subscribe("user1", 1)
+	defer cancel3()
+	if _, ok := <-closed; ok {
+		t.Error("expected closed channel")
+	}
+
+	var nilEvents *events.Events
+	nilEvents.Publish(model.ProcessInstanceEvent{UserId: "user1"})
+
+	limited := events.New()
+	_, cancel4 := limited.Subscribe("user2", 1)
+	defer cancel4()
+	limited.ListenTenants(func() []string { return []string{"user3", "user2"} }, func(event model.ProcessInstanceEvent) {})
+	all, tenants = limited.Tenants()
+	if all || !slices.Equal(tenants, []string{"user2", "user3"}) {
+		t.Error(all, tenants)
+	}
+}
+
+func TestProcessInstanceEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	config.InstanceEventPollInterval = "1s"
+
+	config, wrapperUrl, _, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	t.Run("deploy process long", testDeployProcessWithInput(wrapperClient, "long", resources.LongProcess))
+	t.Run("deploy process finishing", testDeployProcessWithInput(wrapperClient, "finishing", resources.Finishing))
+
+	all, err, _ := wrapperClient.SubscribeProcessInstanceEvents(ctx, helper.Jwt, url.Values{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	deleted, err, _ := wrapperClient.SubscribeProcessInstanceEvents(ctx, helper.Jwt, url.Values{"types": {"deleted"}})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	time.Sleep(2 * time.Second) //first poll
+
+	finishing, err, _ := wrapperClient.StartDeployment(helper.Jwt, "finishing", client.StartOptions{BusinessKey: "events-finishing"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	long, err, _ := wrapperClient.StartDeployment(helper.Jwt, "long", client.StartOptions{BusinessKey: "events-long"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err, _ = wrapperClient.DeleteProcessInstancesByBusinessKey(helper.Jwt, "events-long")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := map[string]string{
+		model.ProcessInstanceEventStarted + "/" + finishing.Id:  "finishing",
+		model.ProcessInstanceEventFinished + "/" + finishing.Id: "finishing",
+		model.ProcessInstanceEventStarted + "/" + long.Id:       "long",
+		model.ProcessInstanceEventDeleted + "/" + long.Id:       "long",
+	}
+	timeout := time.After(30 * time.Second)
+	for len(expected) > 0 {
+		select {
+		case event := <-all:
+			key := event.Type + "/" + event.ProcessInstanceId
+			deploymentId, ok := expected[key]
+			if !ok {
+				t.Errorf("unexpected event %#v", event)
+				continue
+			}
+			if event.DeploymentId != deploymentId {
+				t.Errorf("%#v", event)
+			}
+			delete(expected, key)
+		case <-timeout:
+			t.Error("missing events", expected)
+			return
+		}
+	}
+
+	select {
+	case event := <-deleted:
+		if event.Type != model.ProcessInstanceEventDeleted || event.ProcessInstanceId != long.Id || event.BusinessKey != "events-long" {
+			t.Errorf("%#v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("missing deleted event")
+	}
+}
@@ -0,0 +1,277 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/auth"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	jwtlib "github.com/golang-jwt/jwt"
+)
+
+func TestJwksVerification(t *testing.T) {
+	auth.JwksMinRefetchInterval = 0
+
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := sync.Mutex{}
+	published := map[string]*rsa.PrivateKey{"old": oldKey}
+	jwks := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mux.Lock()
+		defer mux.Unlock()
+		keys := []map[string]string{}
+		for kid, key := range published {
+			keys = append(keys, map[string]string{
+				"kid": kid,
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		json.NewEncoder(writer).Encode(map[string]interface{}{"keys": keys})
+	}))
+	defer jwks.Close()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.JwksUrl = jwks.URL
+	config.JwtIssuer = "test-issuer"
+	config.JwtAudience = "test-audience"
+	config.JwtLeeway = "0s"
+
+	handler, err := auth.NewVerificationMiddleware(config, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writer.Write([]byte(token.GetUserId()))
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	sign := func(kid string, key *rsa.PrivateKey, claims jwtlib.MapClaims) string {
+		token := jwtlib.NewWithClaims(jwtlib.SigningMethodRS256, claims)
+		token.Header["kid"] = kid
+		result, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "Bearer " + result
+	}
+	validClaims := func() jwtlib.MapClaims {
+		return jwtlib.MapClaims{
+			"sub":          "user1",
+			"iss":          "test-issuer",
+			"aud":          []string{"other", "test-audience"},
+			"exp":          time.Now().Add(time.Minute).Unix(),
+			"realm_access": map[string][]string{"roles": {"user"}},
+		}
+	}
+	call := func(token string) (code int) {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		if code := call(sign("old", oldKey, validClaims())); code != http.StatusOK {
+			t.Error(code)
+		}
+	})
+	t.Run("missing token", func(t *testing.T) {
+		if code := call(""); code != http.StatusBadRequest {
+			t.Error(code)
+		}
+	})
+	t.Run("internal admin token", func(t *testing.T) {
+		if code := call(client.InternalAdminToken); code != http.StatusUnauthorized {
+			t.Error(code)
+		}
+	})
+	t.Run("wrong signature", func(t *testing.T) {
+		if code := call(sign("old", newKey, validClaims())); code != http.StatusUnauthorized {
+			t.Error(code)
+		}
+	})
+	t.Run("expired", func(t *testing.T) {
+		claims := validClaims()
+		claims["exp"] = time.Now().Add(-time.Minute).Unix()
+		if code := call(sign("old", oldKey, claims)); code != http.StatusUnauthorized {
+			t.Error(code)
+		}
+	})
+	t.Run("wrong issuer", func(t *testing.T) {
+		claims := validClaims()
+		claims["iss"] = "other"
+		if code := call(sign("old", oldKey, claims)); code != http.StatusUnauthorized {
+			t.Error(code)
+		}
+	})
+	t.Run("wrong audience", func(t *testing.T) {
+		claims := validClaims()
+		claims["aud"] = "other"
+		if code := call(sign("old", oldKey, claims)); code != http.StatusUnauthorized {
+			t.Error(code)
+		}
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		if code := call(sign("new", newKey, validClaims())); code != http.StatusUnauthorized {
+			t.Error(code)
+		}
+	})
+	t.Run("key rotation", func(t *testing.T) {
+		mux.Lock()
+		published["new"] = newKey
+		mux.Unlock()
+		if code := call(sign("new", newKey, validClaims())); code != http.StatusOK {
+			t.Error(code)
+		}
+	})
+}
+
+func TestJwksUnavailable(t *testing.T) {
+	interval := auth.JwksMinRefetchInterval
+	auth.JwksMinRefetchInterval = time.Hour
+	defer func() {
+		auth.JwksMinRefetchInterval = interval
+	}()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := sync.Mutex{}
+	available := true
+	requests := 0
+	jwks := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mux.Lock()
+		defer mux.Unlock()
+		requests++
+		if !available {
+			http.Error(writer, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(writer).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kid": "key",
+			"kty": "RSA",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	defer jwks.Close()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.JwksUrl = jwks.URL
+	config.JwksCacheDuration = "1ms"
+	config.JwtIssuer = ""
+	config.JwtAudience = ""
+
+	verifier, err := auth.NewVerifier(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(kid string) string {
+		token := jwtlib.NewWithClaims(jwtlib.SigningMethodRS256, jwtlib.MapClaims{
+			"sub":          "user1",
+			"exp":          time.Now().Add(time.Minute).Unix(),
+			"realm_access": map[string][]string{"roles": {"user"}},
+		})
+		token.Header["kid"] = kid
+		result, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "Bearer " + result
+	}
+
+	t.Run("initial fetch", func(t *testing.T) {
+		_, err := verifier.Parse(sign("key"))
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	mux.Lock()
+	available = false
+	mux.Unlock()
+	time.Sleep(10 * time.Millisecond)
+
+	t.Run("cached key is used after expiry", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			_, err := verifier.Parse(sign("key"))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := verifier.Parse(sign("other"))
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("refetch is limited", func(t *testing.T) {
+		mux.Lock()
+		defer mux.Unlock()
+		if requests != 1 {
+			t.Error(requests)
+		}
+	})
+}
@@ -66,6 +66,25 @@ func TestListInstances(t *testing.T) {
 	t.Run("list first deployment instances", testListDeploymentInstances(wrapperUrl, deploymentId, 2))
 	t.Run("list second deployment instances", testListDeploymentInstances(wrapperUrl, "withoutInput", 1))
 
+	t.Run("list instances with total", testListInstancesWithTotal(wrapperClient, client.InstanceListOptions{}, 3, 3))
+	t.Run("list instances with limit", testListInstancesWithTotal(wrapperClient, client.InstanceListOptions{OtherArgs: map[string]string{"limit": "1", "sort": "id.desc"}}, 1, 3))
+	t.Run("list instances with offset", testListInstancesWithTotal(wrapperClient, client.InstanceListOptions{OtherArgs: map[string]string{"offset": "2"}}, 1, 3))
+	t.Run("list instances by deployment", testListInstancesWithTotal(wrapperClient, client.InstanceListOptions{OtherArgs: map[string]string{"deployment_id": "withoutInput"}}, 1, 1))
+	t.Run("list suspended instances", testListInstancesWithTotal(wrapperClient, client.InstanceListOptions{OtherArgs: map[string]string{"suspended": "true"}}, 0, 0))
+	t.Run("list active instances", testListInstancesWithTotal(wrapperClient, client.InstanceListOptions{OtherArgs: map[string]string{"suspended": "false"}}, 3, 3))
+	t.Run("list instances with invalid sort", func(t *testing.T) {
+		_, err, code := wrapperClient.ListProcessInstances(helper.Jwt, client.InstanceListOptions{OtherArgs: map[string]string{"sort": "foo.asc"}})
+		if err == nil || code != http.StatusBadRequest {
+			t.Error(err, code)
+		}
+	})
+	t.Run("list instances of unknown deployment", func(t *testing.T) {
+		_, err, code := wrapperClient.ListProcessInstances(helper.Jwt, client.InstanceListOptions{OtherArgs: map[string]string{"deployment_id": "unknown"}})
+		if err == nil || code != http.StatusBadRequest {
+			t.Error(err, code)
+		}
+	})
+
 	t.Run("delete unknown instance", func(t *testing.T) {
 		req, err := http.NewRequest("DELETE", wrapperUrl+"/v2/process-instances/unknown", nil)
 		if err != nil {
@@ -119,6 +138,22 @@ func testListDeploymentInstances(wrapper string, id string, expectedCount int) f
 	}
 }
 
+func testListInstancesWithTotal(c *client.Client, options client.InstanceListOptions, expectedCount int, expectedTotal int64) func(t *testing.T) {
+	return func(t *testing.T) {
+		result, err, _ := c.ListProcessInstancesWithTotal(helper.Jwt, options)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result.Data) != expectedCount {
+			t.Error(len(result.Data), result.Data)
+		}
+		if result.Total != expectedTotal {
+			t.Error(result.Total)
+		}
+	}
+}
+
 func testFetchAndComplete(url string) func(t *testing.T) {
 	return func(t *testing.T) {
 		tasks, err := fetchTestTask(url)
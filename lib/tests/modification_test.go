@@ -0,0 +1,189 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/camunda"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+)
+
+func TestValidateModification(t *testing.T) {
+	valid := []model.ModificationInstruction{
+		{Type: model.ModificationStartBeforeActivity, ActivityId: "a"},
+		{Type: model.ModificationStartAfterActivity, ActivityId: "a"},
+		{Type: model.ModificationStartTransition, TransitionId: "t"},
+		{Type: model.ModificationCancel, ActivityId: "a"},
+		{Type: model.ModificationCancel, ActivityInstanceId: "ai"},
+		{Type: model.ModificationCancel, TransitionInstanceId: "ti"},
+	}
+	for _, instruction := range valid {
+		err := camunda.ValidateModification(model.ProcessInstanceModification{Instructions: []model.ModificationInstruction{instruction}})
+		if err != nil {
+			t.Error(instruction, err)
+		}
+	}
+	invalid := []model.ModificationInstruction{
+		{Type: "foo", ActivityId: "a"},
+		{Type: model.ModificationStartBeforeActivity},
+		{Type: model.ModificationStartAfterActivity, TransitionId: "t"},
+		{Type: model.ModificationStartTransition, ActivityId: "a"},
+		{Type: model.ModificationCancel},
+	}
+	for _, instruction := range invalid {
+		err := camunda.ValidateModification(model.ProcessInstanceModification{Instructions: []model.ModificationInstruction{instruction}})
+		if err == nil {
+			t.Error("expected error", instruction)
+		}
+	}
+	if camunda.ValidateModification(model.ProcessInstanceModification{}) == nil {
+		t.Error("expected error for missing instructions")
+	}
+}
+
+func TestModification(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	config, wrapperUrl, _, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	instance := model.ProcessInstance{}
+	activityInstanceId := ""
+
+	t.Run("deploy process withInput", testDeployProcessWithInput(wrapperClient, "withInput", processWithInput))
+	t.Run("start withInput", testStartProcessWithInputReturnInstance(wrapperUrl, "withInput", map[string]interface{}{"inputTemperature": 30}, &instance))
+
+	getTaskActivityInstance := func(t *testing.T) string {
+		activities, err, _ := wrapperClient.GetProcessInstanceActivities(helper.Jwt, instance.Id)
+		if err != nil {
+			t.Error(err)
+			return ""
+		}
+		if len(activities.ChildActivityInstances) != 1 || activities.ChildActivityInstances[0].ActivityId != "Task_1ol5jfc" {
+			t.Errorf("%#v", activities)
+			return ""
+		}
+		return activities.ChildActivityInstances[0].Id
+	}
+
+	t.Run("get activity instance", func(t *testing.T) {
+		activityInstanceId = getTaskActivityInstance(t)
+	})
+
+	t.Run("invalid modification", func(t *testing.T) {
+		err, code := wrapperClient.ModifyProcessInstance(helper.Jwt, instance.Id, model.ProcessInstanceModification{
+			Instructions: []model.ModificationInstruction{{Type: "foo"}},
+		})
+		if err == nil || code != 400 {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("repeat task", func(t *testing.T) {
+		err, _ := wrapperClient.ModifyProcessInstance(helper.Jwt, instance.Id, model.ProcessInstanceModification{
+			Instructions: []model.ModificationInstruction{
+				{Type: model.ModificationCancel, ActivityInstanceId: activityInstanceId},
+				{Type: model.ModificationStartBeforeActivity, ActivityId: "Task_1ol5jfc"},
+			},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		repeated := getTaskActivityInstance(t)
+		if repeated == activityInstanceId {
+			t.Error("expected new activity instance")
+		}
+		activityInstanceId = repeated
+	})
+
+	t.Run("restart running instance", func(t *testing.T) {
+		err, code := wrapperClient.RestartHistoricProcessInstance(helper.Jwt, instance.Id, model.RestartRequest{})
+		if err == nil || code != 409 {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("skip task", func(t *testing.T) {
+		err, _ := wrapperClient.ModifyProcessInstance(helper.Jwt, instance.Id, model.ProcessInstanceModification{
+			Instructions: []model.ModificationInstruction{
+				{Type: model.ModificationStartAfterActivity, ActivityId: "Task_1ol5jfc"},
+				{Type: model.ModificationCancel, ActivityInstanceId: activityInstanceId},
+			},
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("check finished", testListInstancesWithTotal(wrapperClient, client.InstanceListOptions{}, 0, 0))
+
+	t.Run("restart finished instance", func(t *testing.T) {
+		err, _ := wrapperClient.RestartHistoricProcessInstance(helper.Jwt, instance.Id, model.RestartRequest{})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("check restarted", func(t *testing.T) {
+		list, err, _ := wrapperClient.ListProcessInstances(helper.Jwt, client.InstanceListOptions{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(list) != 1 {
+			t.Errorf("%#v", list)
+			return
+		}
+		variable, err, _ := wrapperClient.GetProcessInstanceVariable(helper.Jwt, list[0].Id, "inputTemperature")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if variable.Value != float64(30) {
+			t.Errorf("%#v", variable)
+		}
+	})
+
+	t.Run("restart unknown instance", func(t *testing.T) {
+		err, code := wrapperClient.RestartHistoricProcessInstance(helper.Jwt, "unknown", model.RestartRequest{})
+		if err == nil || code != 401 {
+			t.Error(err, code)
+		}
+	})
+}
@@ -0,0 +1,217 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/camunda"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/resources"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+)
+
+const processWithFormSchema = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" id="Definitions_1">
+  <bpmn:process id="schema_test" isExecutable="true">
+    <bpmn:startEvent id="StartEvent_1">
+      <bpmn:extensionElements>
+        <camunda:formData>
+          <camunda:formField id="count" label="Count" type="long" defaultValue="3">
+            <camunda:validation>
+              <camunda:constraint name="min" config="1" />
+              <camunda:constraint name="max" config="10" />
+            </camunda:validation>
+          </camunda:formField>
+          <camunda:formField id="name" type="string">
+            <camunda:validation>
+              <camunda:constraint name="required" />
+              <camunda:constraint name="maxlength" config="5" />
+            </camunda:validation>
+          </camunda:formField>
+          <camunda:formField id="mode" label="Mode" type="enum">
+            <camunda:value id="fast" name="Fast" />
+            <camunda:value id="slow" name="Slow" />
+          </camunda:formField>
+          <camunda:formField id="flag" type="boolean" defaultValue="true">
+            <camunda:validation>
+              <camunda:constraint name="readonly" />
+            </camunda:validation>
+          </camunda:formField>
+          <camunda:formField id="internal" type="string">
+            <camunda:properties>
+              <camunda:property id="ignore_on_start" value="true" />
+            </camunda:properties>
+          </camunda:formField>
+        </camunda:formData>
+      </bpmn:extensionElements>
+    </bpmn:startEvent>
+  </bpmn:process>
+</bpmn:definitions>`
+
+func TestStartParameterSchema(t *testing.T) {
+	schema, err := camunda.New(configuration.Config{}, nil, nil, nil).GetStartParameterSchema(processWithFormSchema)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	one, ten, five := float64(1), float64(10), int64(5)
+	expected := model.JsonSchema{
+		Schema: camunda.JsonSchemaVersion,
+		Type:   "object",
+		Properties: map[string]model.JsonSchema{
+			"count": {Type: "integer", Title: "Count", Default: int64(3), Minimum: &one, Maximum: &ten},
+			"name":  {Type: "string", Title: "name", MaxLength: &five},
+			"mode":  {Type: "string", Title: "Mode", Enum: []interface{}{"fast", "slow"}, EnumLabels: map[string]string{"fast": "Fast", "slow": "Slow"}},
+			"flag":  {Type: "boolean", Title: "flag", Default: true, ReadOnly: true},
+		},
+		Required: []string{"name"},
+	}
+	if !reflect.DeepEqual(schema, expected) {
+		t.Errorf("\n%#v\n%#v", schema, expected)
+	}
+
+	validate := func(parameter map[string]interface{}, expectedFields ...string) func(t *testing.T) {
+		return func(t *testing.T) {
+			fields := []string{}
+			for _, fieldErr := range camunda.ValidateStartParameter(schema, parameter) {
+				fields = append(fields, fieldErr.Field)
+			}
+			if !reflect.DeepEqual(fields, append([]string{}, expectedFields...)) {
+				t.Error(fields, expectedFields)
+			}
+		}
+	}
+	t.Run("valid", validate(map[string]interface{}{"name": "foo", "count": float64(10), "mode": "slow", "unknown": 13}))
+	t.Run("missing required", validate(map[string]interface{}{"count": float64(2)}, "name"))
+	t.Run("types", validate(map[string]interface{}{"name": 42, "count": 1.5, "mode": true}, "count", "mode", "name"))
+	t.Run("constraints", validate(map[string]interface{}{"name": "foo bar", "count": float64(0), "mode": "medium", "flag": false}, "count", "flag", "mode", "name"))
+	t.Run("schema without form fields", func(t *testing.T) {
+		schema, err := camunda.New(configuration.Config{}, nil, nil, nil).GetStartParameterSchema(resources.LongProcess)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(schema.Properties) != 0 || len(camunda.ValidateStartParameter(schema, map[string]interface{}{"foo": "bar"})) != 0 {
+			t.Errorf("%#v", schema)
+		}
+	})
+}
+
+func TestStartParameterValidation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	config, wrapperUrl, _, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	deploymentId := "withForm"
+	t.Run("deploy", testDeployProcessWithInput(wrapperClient, deploymentId, processWithForm))
+
+	t.Run("schema", func(t *testing.T) {
+		schema, err, _ := wrapperClient.GetDeploymentParameterSchema(helper.Jwt, deploymentId)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		property, ok := schema.Properties["inputTemperature"]
+		if !ok || property.Type != "integer" || property.Minimum == nil || *property.Minimum != 20 {
+			t.Errorf("%#v", schema)
+		}
+	})
+
+	t.Run("start with invalid input", func(t *testing.T) {
+		_, err, code := wrapperClient.StartDeployment(helper.Jwt, deploymentId, client.StartOptions{Inputs: map[string]interface{}{"inputTemperature": 10}})
+		if err == nil || code != http.StatusBadRequest {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("start with invalid type", func(t *testing.T) {
+		_, err, code := wrapperClient.StartDeployment(helper.Jwt, deploymentId, client.StartOptions{Inputs: map[string]interface{}{"inputTemperature": "foo"}})
+		if err == nil || code != http.StatusBadRequest {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("start with valid input", func(t *testing.T) {
+		_, err, _ := wrapperClient.StartDeployment(helper.Jwt, deploymentId, client.StartOptions{Inputs: map[string]interface{}{"inputTemperature": 30}})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestStartParameterValidationDisabled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	config.StartParameterValidation = false
+
+	config, wrapperUrl, _, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	deploymentId := "withForm"
+	t.Run("deploy", testDeployProcessWithInput(wrapperClient, deploymentId, processWithForm))
+
+	//the input is passed to camunda, which rejects it with its own form validation
+	t.Run("start with invalid input", func(t *testing.T) {
+		_, err, code := wrapperClient.StartDeployment(helper.Jwt, deploymentId, client.StartOptions{Inputs: map[string]interface{}{"inputTemperature": 10}})
+		if err == nil || code == http.StatusBadRequest {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("start with valid input", func(t *testing.T) {
+		_, err, _ := wrapperClient.StartDeployment(helper.Jwt, deploymentId, client.StartOptions{Inputs: map[string]interface{}{"inputTemperature": 30}})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
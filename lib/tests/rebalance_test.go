@@ -0,0 +1,272 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/camunda"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/controller"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/shards"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/shards/cache"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/docker"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/vid"
+)
+
+func TestRebalance(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	controller.RebalanceCacheDelay = 0
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	//records the bodies of incident handler registrations
+	incidentApiMux := sync.Mutex{}
+	incidentApiRequests := []string{}
+	incidentApiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		incidentApiMux.Lock()
+		defer incidentApiMux.Unlock()
+		incidentApiRequests = append(incidentApiRequests, string(body))
+	}))
+	defer incidentApiServer.Close()
+	config.IncidentApiUrl = incidentApiServer.URL
+
+	pgConn, err := docker.Postgres(ctx, &wg, "test")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	config.WrapperDb = pgConn
+	config.ShardingDb = pgConn
+
+	_, sourcePgIp, _, err := docker.PostgresWithNetwork(ctx, &wg, "camunda")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	source, err := docker.Camunda(ctx, &wg, sourcePgIp, "5432")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, targetPgIp, _, err := docker.PostgresWithNetwork(ctx, &wg, "camunda")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	target, err := docker.Camunda(ctx, &wg, targetPgIp, "5432")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	s, err := shards.New(pgConn, cache.None)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, shard := range []string{source, target} {
+		err = s.EnsureShard(shard)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	userId := helper.JwtPayload.GetUserId()
+	err = s.SetShardForUser(userId, source)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	v, err := vid.New(pgConn)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	c := camunda.New(config, v, s, nil)
+	ctrl := controller.New(config, c, v, nil)
+
+	t.Run("deploy", func(t *testing.T) {
+		err, _ := ctrl.Deploy(model.DeploymentMessage{
+			Deployment: model.Deployment{
+				Id:   "rebalance",
+				Name: "rebalance",
+				Diagram: model.Diagram{
+					XmlDeployed: processWithInput,
+					Svg:         helper.SvgExample,
+				},
+				IncidentHandling: &model.IncidentHandling{Restart: true, Notify: true},
+			},
+			UserId: userId,
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	startDeployment := func() (instance model.ProcessInstance, err error) {
+		definitions, err := c.GetDefinitionByDeploymentVid("rebalance", userId)
+		if err != nil {
+			return instance, err
+		}
+		if len(definitions) == 0 {
+			return instance, errors.New("missing definition")
+		}
+		return c.StartProcessGetId(definitions[0].Id, "", userId, nil)
+	}
+
+	t.Run("start", func(t *testing.T) {
+		_, err := startDeployment()
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("start while moving", func(t *testing.T) {
+		err := c.SetUserMoving(userId, time.Now().Add(time.Minute))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err = startDeployment()
+		if !errors.Is(err, camunda.ErrUserMoving) {
+			t.Error(err)
+		}
+		err, code := ctrl.Deploy(model.DeploymentMessage{
+			Deployment: model.Deployment{
+				Id:      "rebalance-while-moving",
+				Name:    "rebalance-while-moving",
+				Diagram: model.Diagram{XmlDeployed: processWithInput, Svg: helper.SvgExample},
+			},
+			UserId: userId,
+		})
+		if !errors.Is(err, camunda.ErrUserMoving) || code != http.StatusServiceUnavailable {
+			t.Error(err, code)
+		}
+		err = c.UnsetUserMoving(userId)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("reject concurrent rebalance", func(t *testing.T) {
+		unlock, locked, err := c.TryLockUserRebalance(userId)
+		if err != nil || !locked {
+			t.Error(err, locked)
+			return
+		}
+		defer unlock()
+		_, err, code := ctrl.Rebalance(model.RebalanceRequest{
+			UserId:           userId,
+			TargetShard:      target,
+			InstanceHandling: model.InstanceHandlingCancel,
+		})
+		if !errors.Is(err, controller.ErrRebalanceRunning) || code != http.StatusConflict {
+			t.Error(err, code)
+		}
+	})
+
+	incidentApiMux.Lock()
+	incidentApiRequests = []string{}
+	incidentApiMux.Unlock()
+
+	t.Run("rebalance", func(t *testing.T) {
+		result, err, _ := ctrl.Rebalance(model.RebalanceRequest{
+			UserId:           userId,
+			TargetShard:      target,
+			InstanceHandling: model.InstanceHandlingCancel,
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !reflect.DeepEqual(result.MovedDeployments, []string{"rebalance"}) || result.CancelledInstances != 1 || len(result.CleanupErrors) > 0 {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("check shard", func(t *testing.T) {
+		shard, err := s.GetShardForUser(userId)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if shard != target {
+			t.Error(shard, target)
+		}
+	})
+
+	t.Run("check source shard", func(t *testing.T) {
+		deployments, err := c.GetRawDeploymentListForShard(source, userId)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(deployments) != 0 {
+			t.Errorf("%#v", deployments)
+		}
+	})
+
+	t.Run("check incident handling", func(t *testing.T) {
+		definitions, err := c.GetDefinitionByDeploymentVid("rebalance", userId)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(definitions) != 1 {
+			t.Errorf("%#v", definitions)
+			return
+		}
+		incidentApiMux.Lock()
+		defer incidentApiMux.Unlock()
+		for _, request := range incidentApiRequests {
+			if strings.Contains(request, definitions[0].Id) {
+				return
+			}
+		}
+		t.Error("missing incident handler registration", definitions[0].Id, incidentApiRequests)
+	})
+
+	t.Run("start after rebalance", func(t *testing.T) {
+		_, err := startDeployment()
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/schedules"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+)
+
+func TestCronExpressionNext(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	start := time.Date(2025, 3, 28, 10, 17, 30, 0, time.UTC) //friday
+	cases := []struct {
+		expr     string
+		from     time.Time
+		expected time.Time
+	}{
+		{expr: "* * * * *", from: start, expected: time.Date(2025, 3, 28, 10, 18, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", from: start, expected: time.Date(2025, 3, 28, 10, 30, 0, 0, time.UTC)},
+		{expr: "0 6 * * MON-FRI", from: start, expected: time.Date(2025, 3, 31, 6, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 JAN *", from: start, expected: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "@daily", from: start, expected: time.Date(2025, 3, 29, 0, 0, 0, 0, time.UTC)},
+		{expr: "@hourly", from: start, expected: time.Date(2025, 3, 28, 11, 0, 0, 0, time.UTC)},
+		{expr: "0 12 * * 7", from: start, expected: time.Date(2025, 3, 30, 12, 0, 0, 0, time.UTC)},
+		{expr: "0-30/10 8,20 * * *", from: start, expected: time.Date(2025, 3, 28, 20, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", from: start, expected: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		//day of month and day of week restricted: one of them has to match
+		{expr: "0 0 1 * MON", from: start, expected: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)},
+		//daylight saving time starts 2025-03-30 in Europe/Berlin
+		{expr: "0 9 * * *", from: time.Date(2025, 3, 29, 12, 0, 0, 0, berlin), expected: time.Date(2025, 3, 30, 7, 0, 0, 0, time.UTC)},
+		{expr: "0 9 * * *", from: time.Date(2025, 3, 28, 12, 0, 0, 0, berlin), expected: time.Date(2025, 3, 29, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		expr, err := schedules.ParseCronExpression(c.expr)
+		if err != nil {
+			t.Error(c.expr, err)
+			continue
+		}
+		next, err := expr.Next(c.from)
+		if err != nil {
+			t.Error(c.expr, err)
+			continue
+		}
+		if !next.Equal(c.expected) {
+			t.Error(c.expr, next, c.expected)
+		}
+	}
+
+	for _, invalid := range []string{"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "@every", "a * * * *"} {
+		_, err := schedules.ParseCronExpression(invalid)
+		if err == nil {
+			t.Error("expected error for", invalid)
+		}
+	}
+}
+
+func TestStartSchedules(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	config.ScheduleCheckInterval = "1s"
+
+	config, wrapperUrl, _, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	t.Run("deploy", testDeployProcessWithInput(wrapperClient, "withForm", processWithForm))
+
+	t.Run("invalid schedules", func(t *testing.T) {
+		for _, schedule := range []client.StartSchedule{
+			{Cron: "* * * *"},
+			{Cron: "* * * * *", TimeZone: "Mars/Olympus"},
+			{Cron: "* * * * *", BusinessKey: "{{.Unknown}}"},
+		} {
+			_, err, code := wrapperClient.CreateDeploymentSchedule(helper.Jwt, "withForm", schedule)
+			if err == nil || code != http.StatusBadRequest {
+				t.Error(schedule, err, code)
+			}
+		}
+		_, err, code := wrapperClient.CreateDeploymentSchedule(helper.Jwt, "unknown", client.StartSchedule{Cron: "* * * * *"})
+		if err == nil || code != http.StatusUnauthorized {
+			t.Error(err, code)
+		}
+	})
+
+	schedule := client.StartSchedule{}
+	t.Run("create", func(t *testing.T) {
+		schedule, err, _ = wrapperClient.CreateDeploymentSchedule(helper.Jwt, "withForm", client.StartSchedule{
+			Cron:        "* * * * *",
+			TimeZone:    "Europe/Berlin",
+			Inputs:      map[string]interface{}{"inputTemperature": 25},
+			BusinessKey: "scheduled-{{.DeploymentId}}",
+			Enabled:     true,
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if schedule.Id == "" || schedule.DeploymentId != "withForm" || schedule.NextRun == nil {
+			t.Errorf("%#v", schedule)
+		}
+		list, err, _ := wrapperClient.ListDeploymentSchedules(helper.Jwt, "withForm")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(list) != 1 || list[0].Id != schedule.Id {
+			t.Errorf("%#v", list)
+		}
+	})
+
+	t.Run("run", func(t *testing.T) {
+		deadline := time.Now().Add(75 * time.Second)
+		for schedule.LastStatus == "" && time.Now().Before(deadline) {
+			time.Sleep(time.Second)
+			schedule, err, _ = wrapperClient.GetSchedule(helper.Jwt, schedule.Id)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		if schedule.LastStatus != model.StartScheduleStatusOk || schedule.LastInstanceId == "" || schedule.LastRun == nil {
+			t.Errorf("%#v", schedule)
+			return
+		}
+		instances, err, _ := wrapperClient.GetHistoricProcessInstances(helper.Jwt, client.InstanceListOptions{BusinessKey: "scheduled-withForm"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(instances) == 0 {
+			t.Error("missing scheduled process-instance")
+		}
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		schedule.Enabled = false
+		updated, err, _ := wrapperClient.UpdateSchedule(helper.Jwt, schedule.Id, schedule)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if updated.Enabled || updated.NextRun != nil || updated.LastInstanceId != schedule.LastInstanceId {
+			t.Errorf("%#v", updated)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err, _ := wrapperClient.DeleteSchedule(helper.Jwt, schedule.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err, code := wrapperClient.GetSchedule(helper.Jwt, schedule.Id)
+		if err == nil || code != http.StatusNotFound {
+			t.Error(err, code)
+		}
+	})
+}
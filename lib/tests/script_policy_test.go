@@ -0,0 +1,153 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/controller"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/resources"
+)
+
+func TestRestrictedScriptPolicy(t *testing.T) {
+	policy := controller.RestrictedScriptPolicy{
+		AllowedFormats:  []string{"javascript"},
+		ForbiddenTokens: []string{"java", "this."},
+	}
+	allowed := []controller.Script{
+		{Format: "javascript", Source: "var a = 1; a + 1;"},
+		{Format: "JavaScript", Source: "inputTemperature * 2"},
+	}
+	for _, script := range allowed {
+		if err := policy.CheckScript(script); err != nil {
+			t.Error(script, err)
+		}
+	}
+	rejected := []controller.Script{
+		{Format: "groovy", Source: "1 + 1"},
+		{Format: "", Source: "1 + 1"},
+		{Format: "javascript", Resource: "deployment://script.js"},
+		{Format: "javascript", Source: "var system = java.lang.System;"},
+		{Format: "javascript", Source: "this.foo"},
+	}
+	for _, script := range rejected {
+		if err := policy.CheckScript(script); err == nil {
+			t.Error("expected error", script)
+		}
+	}
+}
+
+type testScriptPolicy struct{}
+
+func (this testScriptPolicy) CheckScript(script controller.Script) error {
+	if strings.Contains(script.Source, "exit") {
+		return errors.New("exit is not allowed")
+	}
+	return nil
+}
+
+func TestScriptPolicies(t *testing.T) {
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	config.ScriptPolicy = controller.RestrictedScriptPolicyName
+	config.TenantScriptPolicies = map[string]string{
+		"trusted": controller.UnrestrictedScriptPolicyName,
+		"none":    controller.NoScriptsPolicyName,
+		"custom":  "custom",
+		"unknown": "unknown",
+	}
+	ctrl := controller.New(config, nil, nil, nil)
+	ctrl.SetScriptPolicy("custom", testScriptPolicy{})
+
+	validate := func(userId string, xml string) model.DeploymentValidationResult {
+		return ctrl.ValidateDeployment(userId, model.Deployment{
+			Id:      "test",
+			Name:    "test",
+			Diagram: model.Diagram{XmlDeployed: xml, Svg: helper.SvgExample},
+		})
+	}
+
+	t.Run("restricted", func(t *testing.T) {
+		result := validate("user", resources.ScriptTest)
+		if result.Valid || len(result.Errors) != 2 {
+			t.Errorf("%#v", result.Errors)
+			return
+		}
+		for _, msg := range result.Errors {
+			if msg.ElementId != "Task_1buik8b" || msg.Line == 0 || !strings.Contains(msg.Message, "forbidden token") {
+				t.Errorf("%#v", msg)
+			}
+		}
+	})
+
+	t.Run("restricted without scripts", func(t *testing.T) {
+		result := validate("user", processWithInput)
+		if !result.Valid {
+			t.Errorf("%#v", result.Errors)
+		}
+	})
+
+	t.Run("trusted tenant", func(t *testing.T) {
+		result := validate("trusted", resources.ScriptTest)
+		if !result.Valid {
+			t.Errorf("%#v", result.Errors)
+		}
+	})
+
+	t.Run("tenant without scripts", func(t *testing.T) {
+		result := validate("none", resources.ScriptTest)
+		if result.Valid || len(result.Errors) != 2 || result.Errors[0].Message != "scripts are not allowed" {
+			t.Errorf("%#v", result.Errors)
+		}
+	})
+
+	t.Run("custom policy", func(t *testing.T) {
+		result := validate("custom", resources.ScriptTest)
+		if result.Valid || len(result.Errors) != 2 || result.Errors[0].Message != "exit is not allowed" {
+			t.Errorf("%#v", result.Errors)
+		}
+	})
+
+	t.Run("unknown policy", func(t *testing.T) {
+		result := validate("unknown", resources.ScriptTest)
+		if result.Valid {
+			t.Errorf("%#v", result.Errors)
+		}
+	})
+
+	t.Run("deploy rejects scripts", func(t *testing.T) {
+		err, code := ctrl.Deploy(model.DeploymentMessage{
+			Deployment: model.Deployment{
+				Id:      "test",
+				Name:    "test",
+				Diagram: model.Diagram{XmlDeployed: resources.ScriptTest, Svg: helper.SvgExample},
+			},
+			UserId: "user",
+		})
+		if err == nil || code != 400 || !strings.Contains(err.Error(), "script policy violation") || !strings.Contains(err.Error(), "Task_1buik8b") {
+			t.Error(err, code)
+		}
+	})
+}
@@ -2,24 +2,55 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/api"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/camunda"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/controller"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/idempotency"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/jobs"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/metrics"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/schedules"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/shards"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/shards/cache"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/docker"
 	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/vid"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/webhooks"
+	incidentclient "github.com/SENERGY-Platform/process-incident-api/lib/client"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"time"
 )
 
 func CreateTestEnv(ctx context.Context, wg *sync.WaitGroup, initConf configuration.Config) (config configuration.Config, wrapperUrl string, shard string, err error) {
+	return CreateTestEnvWithPublisher(ctx, wg, initConf, nil)
+}
+
+// CreateTestEnvWithPublisher creates a test environment, which publishes change events to publisher
+func CreateTestEnvWithPublisher(ctx context.Context, wg *sync.WaitGroup, initConf configuration.Config, publisher controller.Publisher) (config configuration.Config, wrapperUrl string, shard string, err error) {
 	config = initConf
-	incidentApiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	//stores registered on-incident handlers and returns them by process-definition id
+	incidentApiMux := sync.Mutex{}
+	incidentHandlers := map[string]incidentclient.OnIncident{}
+	incidentApiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		incidentApiMux.Lock()
+		defer incidentApiMux.Unlock()
+		if r.Method == http.MethodGet {
+			handler, ok := incidentHandlers[strings.TrimPrefix(r.URL.Path, "/on-incident-handler/")]
+			if !ok || !strings.HasPrefix(r.URL.Path, "/on-incident-handler/") {
+				http.Error(w, "not found", http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(handler)
+			return
+		}
+		handler := incidentclient.OnIncident{}
+		if json.NewDecoder(r.Body).Decode(&handler) == nil && handler.ProcessDefinitionId != "" {
+			incidentHandlers[handler.ProcessDefinitionId] = handler
+		}
+	}))
 	go func() {
 		<-ctx.Done()
 		incidentApiServer.Close()
@@ -61,12 +92,72 @@ func CreateTestEnv(ctx context.Context, wg *sync.WaitGroup, initConf configurati
 
 	c := camunda.New(config, v, s, nil)
 
-	ctrl := controller.New(config, c, v, nil)
+	j, err := jobs.New(config.WrapperDb)
+	if err != nil {
+		return config, wrapperUrl, shard, err
+	}
+
+	idem, err := idempotency.New(config.WrapperDb)
+	if err != nil {
+		return config, wrapperUrl, shard, err
+	}
+
+	sched, err := schedules.New(config.WrapperDb)
+	if err != nil {
+		return config, wrapperUrl, shard, err
+	}
+
+	hooks, err := webhooks.New(config.WrapperDb)
+	if err != nil {
+		return config, wrapperUrl, shard, err
+	}
+
+	ctrl := controller.New(config, c, v, nil).
+		SetJobs(j).
+		SetIdempotency(idem).
+		SetSchedules(sched).
+		SetWebhooks(hooks).
+		SetPublisher(publisher)
+	err = ctrl.StartDeploymentJobRecovery(ctx)
+	if err != nil {
+		return config, wrapperUrl, shard, err
+	}
+
+	err = ctrl.StartDeploymentWorkers(ctx)
+	if err != nil {
+		return config, wrapperUrl, shard, err
+	}
+
+	err = ctrl.StartIdempotencyKeyCleanup(ctx)
+	if err != nil {
+		return config, wrapperUrl, shard, err
+	}
+
+	err = ctrl.StartScheduler(ctx)
+	if err != nil {
+		return config, wrapperUrl, shard, err
+	}
+
+	err = ctrl.StartInstanceEventPoller(ctx)
+	if err != nil {
+		return config, wrapperUrl, shard, err
+	}
+
+	err = ctrl.StartWebhookDelivery(ctx)
+	if err != nil {
+		return config, wrapperUrl, shard, err
+	}
+
+	err = ctrl.StartChangePublication(ctx)
+	if err != nil {
+		return config, wrapperUrl, shard, err
+	}
 
 	httpServer := httptest.NewServer(api.GetRouter(config, c, ctrl, metrics.New()))
 	wg.Add(1)
 	go func() {
 		<-ctx.Done()
+		c.Events().Close() //ends event streams, which would otherwise block httpServer.Close()
 		httpServer.Close()
 		wg.Done()
 	}()
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+)
+
+func TestStartWithRequestBody(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	config, wrapperUrl, shard, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	t.Run("deploy process with input", testDeployProcessWithInput(wrapperClient, "withInput", processWithInput))
+	t.Run("deploy process with form", testDeployProcessWithInput(wrapperClient, "withForm", processWithForm))
+
+	t.Run("start with typed variable", func(t *testing.T) {
+		result, err, _ := wrapperClient.StartDeploymentWithRequest(helper.Jwt, "withInput", client.StartRequest{
+			BusinessKey: "typed",
+			Variables: map[string]client.Variable{
+				"inputTemperature": {Value: "30", Type: "String"},
+			},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Id == "" || result.BusinessKey != "typed" || result.Variables != nil {
+			t.Errorf("%#v", result)
+		}
+	})
+	t.Run("check and finish task with typed variable", testCheckProcessWithInputTask(shard, "30"))
+
+	t.Run("start with variables in return", func(t *testing.T) {
+		result, err, _ := wrapperClient.StartDeploymentWithRequest(helper.Jwt, "withForm", client.StartRequest{
+			Variables: map[string]client.Variable{
+				"inputTemperature": {Value: 21},
+			},
+			WithVariablesInReturn: true,
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Id == "" || result.Variables["inputTemperature"].Value != float64(21) {
+			t.Errorf("%#v", result)
+		}
+	})
+	t.Run("check and finish task with variables in return", testCheckProcessWithInputTask(shard, float64(21)))
+
+	t.Run("start with invalid variable", func(t *testing.T) {
+		_, err, code := wrapperClient.StartDeploymentWithRequest(helper.Jwt, "withForm", client.StartRequest{
+			Variables: map[string]client.Variable{
+				"inputTemperature": {Value: 10},
+			},
+		})
+		if err == nil || code != http.StatusBadRequest {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("start process-definition", func(t *testing.T) {
+		deployment, err, _ := wrapperClient.StartDeploymentWithRequest(helper.Jwt, "withForm", client.StartRequest{
+			Variables: map[string]client.Variable{
+				"inputTemperature": {Value: 42},
+			},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		result, err, _ := wrapperClient.StartProcessDefinitionWithRequest(helper.Jwt, deployment.DefinitionId, client.StartRequest{
+			BusinessKey: "definition",
+			Variables: map[string]client.Variable{
+				"inputTemperature": {Value: 42},
+			},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.DefinitionId != deployment.DefinitionId || result.BusinessKey != "definition" {
+			t.Errorf("%#v", result)
+		}
+	})
+}
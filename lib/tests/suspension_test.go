@@ -0,0 +1,116 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/resources"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+)
+
+func TestSuspension(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	config, wrapperUrl, _, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	t.Run("deploy process long", testDeployProcessWithInput(wrapperClient, "long", resources.LongProcess))
+
+	instance := model.ProcessInstance{}
+	t.Run("start process", testStartProcessWithInputReturnInstance(wrapperUrl, "long", nil, &instance))
+
+	suspended := client.InstanceListOptions{OtherArgs: map[string]string{"suspended": "true"}}
+
+	t.Run("suspend instance", func(t *testing.T) {
+		err, _ := wrapperClient.SetProcessInstanceSuspended(helper.Jwt, instance.Id, true)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("check suspended instances", testListInstancesWithTotal(wrapperClient, suspended, 1, 1))
+
+	t.Run("resume instance", func(t *testing.T) {
+		err, _ := wrapperClient.SetProcessInstanceSuspended(helper.Jwt, instance.Id, false)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("check resumed instances", testListInstancesWithTotal(wrapperClient, suspended, 0, 0))
+
+	t.Run("suspend unknown instance", func(t *testing.T) {
+		err, code := wrapperClient.SetProcessInstanceSuspended(helper.Jwt, "unknown", true)
+		if err == nil || code != 404 {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("suspend deployment", func(t *testing.T) {
+		err, _ := wrapperClient.SetDeploymentSuspended(helper.Jwt, "long", true)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("check suspended deployment instances", testListInstancesWithTotal(wrapperClient, suspended, 1, 1))
+	t.Run("start suspended deployment", func(t *testing.T) {
+		_, err, _ := wrapperClient.StartDeployment(helper.Jwt, "long", client.StartOptions{})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("resume deployment", func(t *testing.T) {
+		err, _ := wrapperClient.SetDeploymentSuspended(helper.Jwt, "long", false)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("check resumed deployment instances", testListInstancesWithTotal(wrapperClient, suspended, 0, 0))
+	t.Run("start resumed deployment", func(t *testing.T) {
+		_, err, _ := wrapperClient.StartDeployment(helper.Jwt, "long", client.StartOptions{})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("suspend unknown deployment", func(t *testing.T) {
+		err, code := wrapperClient.SetDeploymentSuspended(helper.Jwt, "unknown", true)
+		if err == nil || code != 401 {
+			t.Error(err, code)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/controller"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/resources"
+)
+
+const validationTestProcess = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" id="Definitions_1">
+  <bpmn:process id="test" isExecutable="true">
+    <bpmn:startEvent id="StartEvent_1" />
+    <bpmn:sequenceFlow id="Flow_1" sourceRef="StartEvent_1" targetRef="Task_1" />
+    <bpmn:serviceTask id="Task_1" camunda:type="external" camunda:topic="test">
+      <bpmn:extensionElements>
+        <camunda:foo />
+      </bpmn:extensionElements>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="Flow_2" sourceRef="Task_1" targetRef="Missing" />
+  </bpmn:process>
+</bpmn:definitions>`
+
+func TestValidateDeployment(t *testing.T) {
+	ctrl := controller.New(configuration.Config{}, nil, nil, nil)
+	validate := func(xml string) model.DeploymentValidationResult {
+		return ctrl.ValidateDeployment("user", model.Deployment{
+			Id:      "test",
+			Name:    "test",
+			Diagram: model.Diagram{XmlDeployed: xml, Svg: helper.SvgExample},
+		})
+	}
+
+	t.Run("valid processes", func(t *testing.T) {
+		for _, xml := range []string{processWithInput, resources.LongProcess, resources.Finishing, resources.ScriptTest, resources.FormFieldTest} {
+			result := validate(xml)
+			if !result.Valid || len(result.Errors) != 0 {
+				t.Errorf("%#v", result)
+			}
+		}
+	})
+
+	t.Run("missing fields", func(t *testing.T) {
+		result := ctrl.ValidateDeployment("user", model.Deployment{})
+		if result.Valid || len(result.Errors) != 3 {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		result := validate("<bpmn:definitions>\n<foo>\n</bpmn:definitions>")
+		if result.Valid || len(result.Errors) != 1 || result.Errors[0].Line != 3 {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("structural errors", func(t *testing.T) {
+		result := validate(validationTestProcess)
+		if result.Valid {
+			t.Errorf("%#v", result)
+			return
+		}
+		if len(result.Errors) != 1 || result.Errors[0].ElementId != "Flow_2" || result.Errors[0].Line != 11 || result.Errors[0].Column != 5 {
+			t.Errorf("%#v", result.Errors)
+		}
+		if len(result.Warnings) != 1 || result.Warnings[0].Line != 8 || !strings.Contains(result.Warnings[0].Message, "camunda:foo") {
+			t.Errorf("%#v", result.Warnings)
+		}
+	})
+
+	t.Run("missing start event", func(t *testing.T) {
+		result := validate(strings.Replace(validationTestProcess, `<bpmn:startEvent id="StartEvent_1" />`, "", 1))
+		if result.Valid || len(result.Errors) != 3 || result.Errors[0].ElementId != "test" {
+			t.Errorf("%#v", result.Errors)
+		}
+	})
+
+	t.Run("no executable process", func(t *testing.T) {
+		result := validate(strings.Replace(validationTestProcess, `isExecutable="true"`, `isExecutable="false"`, 1))
+		if result.Valid || len(result.Warnings) != 2 || result.Errors[0].Message != "no executable process found" {
+			t.Errorf("%#v", result)
+		}
+	})
+}
@@ -0,0 +1,403 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/camunda"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+)
+
+func TestNormalizeVariable(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    model.Variable
+		expected interface{}
+	}{
+		{
+			name:     "string",
+			input:    model.Variable{Type: "String", Value: "foo"},
+			expected: "foo",
+		},
+		{
+			name:     "double",
+			input:    model.Variable{Type: "Double", Value: 4.2},
+			expected: 4.2,
+		},
+		{
+			name:     "json",
+			input:    model.Variable{Type: "Json", Value: `{"foo":[1,"bar"]}`},
+			expected: map[string]interface{}{"foo": []interface{}{float64(1), "bar"}},
+		},
+		{
+			name:     "invalid json",
+			input:    model.Variable{Type: "Json", Value: `{foo`},
+			expected: `{foo`,
+		},
+		{
+			name: "json object",
+			input: model.Variable{Type: "Object", Value: `{"foo":"bar"}`, ValueInfo: map[string]interface{}{
+				"objectTypeName":          "java.util.HashMap",
+				"serializationDataFormat": "application/json",
+			}},
+			expected: map[string]interface{}{"foo": "bar"},
+		},
+		{
+			name: "java object",
+			input: model.Variable{Type: "Object", Value: "rO0ABXNyABFqYXZh", ValueInfo: map[string]interface{}{
+				"objectTypeName":          "java.util.HashMap",
+				"serializationDataFormat": "application/x-java-serialized-object",
+			}},
+			expected: "rO0ABXNyABFqYXZh",
+		},
+		{
+			name:     "date",
+			input:    model.Variable{Type: "Date", Value: "2013-06-30T21:24:04.120+0200"},
+			expected: "2013-06-30T21:24:04.12+02:00",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual := camunda.NormalizeVariable(c.input)
+			if !reflect.DeepEqual(actual.Value, c.expected) {
+				t.Errorf("%#v", actual.Value)
+			}
+			if actual.Type != c.input.Type {
+				t.Error(actual.Type)
+			}
+		})
+	}
+}
+
+func TestSerializeVariable(t *testing.T) {
+	cases := []struct {
+		name         string
+		input        model.Variable
+		expected     interface{}
+		expectedType string
+		expectError  bool
+	}{
+		{
+			name:         "untyped string",
+			input:        model.Variable{Value: "foo"},
+			expected:     "foo",
+			expectedType: "",
+		},
+		{
+			name:         "untyped number",
+			input:        model.Variable{Value: 4.2},
+			expected:     4.2,
+			expectedType: "",
+		},
+		{
+			name:         "untyped object",
+			input:        model.Variable{Value: map[string]interface{}{"foo": "bar"}},
+			expected:     `{"foo":"bar"}`,
+			expectedType: "Json",
+		},
+		{
+			name:         "untyped list",
+			input:        model.Variable{Value: []interface{}{"foo", float64(1)}},
+			expected:     `["foo",1]`,
+			expectedType: "Json",
+		},
+		{
+			name:         "json string",
+			input:        model.Variable{Type: "Json", Value: `{"foo":"bar"}`},
+			expected:     `"{\"foo\":\"bar\"}"`,
+			expectedType: "Json",
+		},
+		{
+			name:         "json number",
+			input:        model.Variable{Type: "Json", Value: float64(1)},
+			expected:     `1`,
+			expectedType: "Json",
+		},
+		{
+			name: "json object",
+			input: model.Variable{Type: "Object", Value: map[string]interface{}{"foo": "bar"}, ValueInfo: map[string]interface{}{
+				"objectTypeName":          "java.util.HashMap",
+				"serializationDataFormat": "application/json",
+			}},
+			expected:     `{"foo":"bar"}`,
+			expectedType: "Object",
+		},
+		{
+			name: "java object",
+			input: model.Variable{Type: "Object", Value: map[string]interface{}{"foo": "bar"}, ValueInfo: map[string]interface{}{
+				"objectTypeName": "java.util.HashMap",
+			}},
+			expectError: true,
+		},
+		{
+			name:         "rfc3339 date",
+			input:        model.Variable{Type: "Date", Value: "2013-06-30T21:24:04.12+02:00"},
+			expected:     "2013-06-30T21:24:04.120+0200",
+			expectedType: "Date",
+		},
+		{
+			name:         "camunda date",
+			input:        model.Variable{Type: "Date", Value: "2013-06-30T21:24:04.120+0200"},
+			expected:     "2013-06-30T21:24:04.120+0200",
+			expectedType: "Date",
+		},
+		{
+			name:         "typed integer",
+			input:        model.Variable{Type: "Integer", Value: float64(42)},
+			expected:     float64(42),
+			expectedType: "Integer",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := camunda.SerializeVariable(c.input)
+			if c.expectError {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(actual.Value, c.expected) {
+				t.Errorf("%#v", actual.Value)
+			}
+			if actual.Type != c.expectedType {
+				t.Error(actual.Type)
+			}
+		})
+	}
+}
+
+func TestVariableRoundTrip(t *testing.T) {
+	cases := []struct {
+		name  string
+		input model.Variable
+	}{
+		{
+			name:  "json object",
+			input: model.Variable{Type: "Json", Value: `{"foo":[1,"bar"]}`},
+		},
+		{
+			name:  "json string",
+			input: model.Variable{Type: "Json", Value: `"hello"`},
+		},
+		{
+			name:  "json number",
+			input: model.Variable{Type: "Json", Value: `42`},
+		},
+		{
+			name: "json serialized object",
+			input: model.Variable{Type: "Object", Value: `"hello"`, ValueInfo: map[string]interface{}{
+				"objectTypeName":          "java.lang.String",
+				"serializationDataFormat": "application/json",
+			}},
+		},
+		{
+			name:  "date",
+			input: model.Variable{Type: "Date", Value: "2013-06-30T21:24:04.120+0200"},
+		},
+		{
+			name:  "date without millis",
+			input: model.Variable{Type: "Date", Value: "2013-06-30T21:24:04.000+0000"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := camunda.SerializeVariable(camunda.NormalizeVariable(c.input))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(actual.Value, c.input.Value) {
+				t.Errorf("%#v", actual.Value)
+			}
+			if actual.Type != c.input.Type {
+				t.Error(actual.Type)
+			}
+		})
+	}
+}
+
+func TestVarRead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	config, wrapperUrl, _, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	instance := model.ProcessInstance{}
+
+	t.Run("deploy process withInput", testDeployProcessWithInput(wrapperClient, "withInput", processWithInput))
+	t.Run("start withInput", testStartProcessWithInputReturnInstance(wrapperUrl, "withInput", map[string]interface{}{"inputTemperature": 30}, &instance))
+	t.Run("set variable", testSetProcessVariable(wrapperUrl, instance.Id, "debug", "foo"))
+
+	t.Run("read variables", func(t *testing.T) {
+		result, err, _ := wrapperClient.GetProcessInstanceVariables(helper.Jwt, instance.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result["inputTemperature"].Value != float64(30) {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("read variable", func(t *testing.T) {
+		result, err, _ := wrapperClient.GetProcessInstanceVariable(helper.Jwt, instance.Id, "inputTemperature")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Value != float64(30) {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("read updated variable", func(t *testing.T) {
+		result, err, _ := wrapperClient.GetProcessInstanceVariable(helper.Jwt, instance.Id, "debug")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Value != "foo" || result.Type != "String" {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("update variables", func(t *testing.T) {
+		err, _ := wrapperClient.UpdateProcessInstanceVariables(helper.Jwt, instance.Id, model.VariableModifications{
+			Modifications: model.VariableMap{
+				"inputTemperature": {Value: 21, Type: "Integer"},
+				"settings":         {Value: map[string]interface{}{"foo": []interface{}{"bar"}}},
+			},
+			Deletions: []string{"debug"},
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("read updated variables", func(t *testing.T) {
+		result, err, _ := wrapperClient.GetProcessInstanceVariables(helper.Jwt, instance.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result["inputTemperature"].Value != float64(21) || result["inputTemperature"].Type != "Integer" {
+			t.Errorf("%#v", result["inputTemperature"])
+		}
+		if !reflect.DeepEqual(result["settings"].Value, map[string]interface{}{"foo": []interface{}{"bar"}}) || result["settings"].Type != "Json" {
+			t.Errorf("%#v", result["settings"])
+		}
+		if _, ok := result["debug"]; ok {
+			t.Errorf("%#v", result["debug"])
+		}
+	})
+
+	t.Run("update date and json string", func(t *testing.T) {
+		err, _ := wrapperClient.UpdateProcessInstanceVariables(helper.Jwt, instance.Id, model.VariableModifications{
+			Modifications: model.VariableMap{
+				"due":      {Value: "2013-06-30T21:24:04.12+02:00", Type: "Date"},
+				"greeting": {Value: "hello", Type: "Json"},
+			},
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("write back read variables", func(t *testing.T) {
+		result, err, _ := wrapperClient.GetProcessInstanceVariables(helper.Jwt, instance.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err, _ = wrapperClient.UpdateProcessInstanceVariables(helper.Jwt, instance.Id, model.VariableModifications{
+			Modifications: model.VariableMap{
+				"due":      result["due"],
+				"greeting": result["greeting"],
+				"settings": result["settings"],
+			},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		again, err, _ := wrapperClient.GetProcessInstanceVariables(helper.Jwt, instance.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		for _, name := range []string{"due", "greeting", "settings"} {
+			if !reflect.DeepEqual(again[name].Value, result[name].Value) || again[name].Type != result[name].Type {
+				t.Errorf("%v: %#v %#v", name, again[name], result[name])
+			}
+		}
+		if again["greeting"].Value != "hello" || again["greeting"].Type != "Json" {
+			t.Errorf("%#v", again["greeting"])
+		}
+	})
+
+	t.Run("update with invalid object", func(t *testing.T) {
+		err, code := wrapperClient.UpdateProcessInstanceVariables(helper.Jwt, instance.Id, model.VariableModifications{
+			Modifications: model.VariableMap{
+				"settings": {Value: map[string]interface{}{"foo": "bar"}, Type: "Object"},
+			},
+		})
+		if err == nil || code != 400 {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("read unknown variable", func(t *testing.T) {
+		_, err, code := wrapperClient.GetProcessInstanceVariable(helper.Jwt, instance.Id, "unknown")
+		if err == nil || code != 404 {
+			t.Error(err, code)
+		}
+	})
+
+	t.Run("read variables of unknown instance", func(t *testing.T) {
+		_, err, code := wrapperClient.GetProcessInstanceVariables(helper.Jwt, "unknown")
+		if err == nil || code != 404 {
+			t.Error(err, code)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+)
+
+func TestDeploymentVersions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	config.DeploymentVersionsKept = 1
+
+	config, wrapperUrl, _, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	first := model.ProcessInstance{}
+	second := model.ProcessInstance{}
+
+	start := func(instance *model.ProcessInstance) func(t *testing.T) {
+		return func(t *testing.T) {
+			var err error
+			*instance, err, _ = wrapperClient.StartDeployment(helper.Jwt, "versioned", client.StartOptions{Inputs: map[string]interface{}{"inputTemperature": 30}})
+			if err != nil {
+				t.Error(err)
+			}
+		}
+	}
+
+	checkVersions := func(expected ...model.DeploymentVersion) func(t *testing.T) {
+		return func(t *testing.T) {
+			versions, err, _ := wrapperClient.GetDeploymentVersions(helper.Jwt, "versioned")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if len(versions) != len(expected) {
+				t.Errorf("%#v", versions)
+				return
+			}
+			for i, version := range versions {
+				if version.Version != expected[i].Version || version.Active != expected[i].Active || version.CreatedAt.IsZero() {
+					t.Errorf("%#v", versions)
+					return
+				}
+			}
+		}
+	}
+
+	t.Run("deploy version 1", testDeployProcessWithInput(wrapperClient, "versioned", processWithInput))
+	t.Run("start version 1", start(&first))
+	t.Run("deploy version 2", testDeployProcessWithInput(wrapperClient, "versioned", processWithInput))
+	t.Run("check versions after redeploy", checkVersions(
+		model.DeploymentVersion{Version: 2, Active: true},
+		model.DeploymentVersion{Version: 1, Active: false},
+	))
+	t.Run("check deployment list", func(t *testing.T) {
+		list, err, _ := wrapperClient.ListDeployments(helper.Jwt, client.DeploymentListOptions{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(list) != 1 || list[0].Id != "versioned" {
+			t.Errorf("%#v", list)
+		}
+	})
+	t.Run("instance of version 1 still running", testListInstancesWithTotal(wrapperClient, client.InstanceListOptions{}, 1, 1))
+	t.Run("start version 2", start(&second))
+	t.Run("check new definition", func(t *testing.T) {
+		if first.DefinitionId == second.DefinitionId {
+			t.Error(first.DefinitionId, second.DefinitionId)
+		}
+	})
+
+	t.Run("rollback unknown version", func(t *testing.T) {
+		err, code := wrapperClient.RollbackDeployment(helper.Jwt, "versioned", 42)
+		if err == nil || code != 404 {
+			t.Error(err, code)
+		}
+	})
+	t.Run("rollback to version 1", func(t *testing.T) {
+		err, _ := wrapperClient.RollbackDeployment(helper.Jwt, "versioned", 1)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("check versions after rollback", checkVersions(
+		model.DeploymentVersion{Version: 3, Active: true},
+		model.DeploymentVersion{Version: 2, Active: false},
+	))
+	t.Run("start rolled back version", func(t *testing.T) {
+		instance := model.ProcessInstance{}
+		start(&instance)(t)
+		if instance.DefinitionId == first.DefinitionId || instance.DefinitionId == second.DefinitionId {
+			t.Error(instance.DefinitionId, first.DefinitionId, second.DefinitionId)
+		}
+	})
+
+	t.Run("deploy version 4", testDeployProcessWithInput(wrapperClient, "versioned", processWithInput))
+	t.Run("check exceeding versions removed", checkVersions(
+		model.DeploymentVersion{Version: 4, Active: true},
+		model.DeploymentVersion{Version: 3, Active: false},
+	))
+	t.Run("instances of removed versions are removed", testListInstancesWithTotal(wrapperClient, client.InstanceListOptions{}, 1, 1))
+
+	t.Run("delete deployment", func(t *testing.T) {
+		err, _ := wrapperClient.DeleteDeployment(client.InternalAdminToken, helper.JwtPayload.GetUserId(), "versioned")
+		if err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("instances removed", testListInstancesWithTotal(wrapperClient, client.InstanceListOptions{}, 0, 0))
+	t.Run("versions removed", func(t *testing.T) {
+		_, err, code := wrapperClient.GetDeploymentVersions(helper.Jwt, "versioned")
+		if err == nil || code != 401 {
+			t.Error(err, code)
+		}
+	})
+}
@@ -34,6 +34,7 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"sync"
 	"testing"
 )
@@ -299,3 +300,75 @@ func TestVid(t *testing.T) {
 		return
 	}
 }
+
+func TestVidConcurrentVersions(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pgStr, _, _, err := docker.PostgresWithNetwork(ctx, wg, "vid_relations")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	v, err := vid.New(pgStr)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	count := 20
+	saves := sync.WaitGroup{}
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		saves.Add(1)
+		go func(i int) {
+			defer saves.Done()
+			errs <- v.SaveVidRelation("v1", "d"+strconv.Itoa(i))
+		}(i)
+	}
+	saves.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	versions, err := v.GetVersions("v1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(versions) != count {
+		t.Error("unexpected result:", versions)
+		return
+	}
+	activeCount := 0
+	for i, version := range versions {
+		if version.Version != count-i {
+			t.Error("unexpected version:", i, version)
+		}
+		if version.Active {
+			activeCount++
+		}
+	}
+	if activeCount != 1 || !versions[0].Active {
+		t.Error("unexpected active versions:", versions)
+	}
+
+	vids, active, err := v.GetVirtualIdsAndStates([]string{versions[0].DeploymentId, versions[1].DeploymentId, "unknown"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(vids) != 2 || vids[versions[0].DeploymentId] != "v1" || vids[versions[1].DeploymentId] != "v1" {
+		t.Error("unexpected vids:", vids)
+	}
+	if !active[versions[0].DeploymentId] || active[versions[1].DeploymentId] {
+		t.Error("unexpected states:", active)
+	}
+}
@@ -0,0 +1,241 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/client"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/configuration"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/helper"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/resources"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/tests/server"
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/webhooks"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	body := []byte(`{"delivery_id":"d"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("1700000000." + string(body)))
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if signature := webhooks.Sign("secret", 1700000000, body); signature != expected {
+		t.Error(signature, expected)
+	}
+	if !webhooks.Verify("secret", 1700000000, body, expected) {
+		t.Error("expected valid signature")
+	}
+	if webhooks.Verify("other", 1700000000, body, expected) || webhooks.Verify("secret", 1700000001, body, expected) {
+		t.Error("expected invalid signature")
+	}
+}
+
+func TestWebhookMatches(t *testing.T) {
+	webhook := model.Webhook{UserId: "user", Enabled: true, Events: []string{model.ProcessInstanceEventFinished}, DeploymentId: "depl"}
+	event := model.ProcessInstanceEvent{UserId: "user", Type: model.ProcessInstanceEventFinished, DeploymentId: "depl", ProcessDefinitionId: "def", BusinessKey: "key"}
+	if !webhooks.Matches(webhook, event) {
+		t.Error("expected match")
+	}
+	for name, w := range map[string]model.Webhook{
+		"disabled":      {UserId: "user"},
+		"other user":    {UserId: "other", Enabled: true},
+		"other type":    {UserId: "user", Enabled: true, Events: []string{model.ProcessInstanceEventStarted}},
+		"other depl":    {UserId: "user", Enabled: true, DeploymentId: "other"},
+		"other def":     {UserId: "user", Enabled: true, DefinitionId: "other"},
+		"other bk":      {UserId: "user", Enabled: true, BusinessKey: "other"},
+		"other bk type": {UserId: "user", Enabled: true, BusinessKey: "key", Events: []string{model.ProcessInstanceEventDeleted}},
+	} {
+		if webhooks.Matches(w, event) {
+			t.Error("unexpected match", name)
+		}
+	}
+	if !webhooks.Matches(model.Webhook{UserId: "user", Enabled: true, DefinitionId: "def", BusinessKey: "key"}, event) {
+		t.Error("expected match without event filter")
+	}
+}
+
+func TestWebhooks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	mux := sync.Mutex{}
+	secret := "test-secret"
+	requests := 0
+	received := []model.WebhookPayload{}
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.Lock()
+		defer mux.Unlock()
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhooks.TimestampHeader), 10, 64)
+		if !webhooks.Verify(secret, timestamp, body, r.Header.Get(webhooks.SignatureHeader)) {
+			t.Error("invalid signature")
+		}
+		payload := model.WebhookPayload{}
+		err := json.Unmarshal(body, &payload)
+		if err != nil || payload.DeliveryId != r.Header.Get(webhooks.DeliveryHeader) || payload.Event.Type != r.Header.Get(webhooks.EventHeader) {
+			t.Error(err, payload)
+		}
+		received = append(received, payload)
+	}))
+	defer receiver.Close()
+
+	config, err := configuration.LoadConfig("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	config.InstanceEventPollInterval = "1s"
+	config.WebhookDeliveryInterval = "1s"
+	config.WebhookRetryBackoff = "1s"
+	config.WebhookAllowPrivateTargets = true
+
+	config, wrapperUrl, _, err := server.CreateTestEnv(ctx, &wg, config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wrapperClient := client.New(wrapperUrl)
+
+	t.Run("deploy process finishing", testDeployProcessWithInput(wrapperClient, "finishing", resources.Finishing))
+
+	t.Run("invalid webhooks", func(t *testing.T) {
+		for _, webhook := range []client.Webhook{
+			{Url: "ftp://example.com", Enabled: true},
+			{Url: "/relative", Enabled: true},
+			{Url: "http://example.com", Events: []string{"unknown"}, Enabled: true},
+		} {
+			_, err, code := wrapperClient.CreateWebhook(helper.Jwt, webhook)
+			if err == nil || code != http.StatusBadRequest {
+				t.Error(webhook, err, code)
+			}
+		}
+		_, err, code := wrapperClient.CreateWebhook(helper.Jwt, client.Webhook{Url: receiver.URL, DeploymentId: "unknown", Enabled: true})
+		if err == nil || code != http.StatusUnauthorized {
+			t.Error(err, code)
+		}
+	})
+
+	webhook := client.Webhook{}
+	t.Run("create", func(t *testing.T) {
+		webhook, err, _ = wrapperClient.CreateWebhook(helper.Jwt, client.Webhook{
+			Url:          receiver.URL,
+			Secret:       secret,
+			Events:       []string{model.ProcessInstanceEventStarted, model.ProcessInstanceEventFinished},
+			DeploymentId: "finishing",
+			Enabled:      true,
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if webhook.Id == "" || webhook.Secret != secret {
+			t.Errorf("%#v", webhook)
+		}
+		get, err, _ := wrapperClient.GetWebhook(helper.Jwt, webhook.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if get.Secret != "" || get.Url != receiver.URL {
+			t.Errorf("%#v", get)
+		}
+	})
+
+	instance := client.ProcessInstance{}
+	t.Run("start", func(t *testing.T) {
+		time.Sleep(2 * time.Second) //first poll
+		instance, err, _ = wrapperClient.StartDeployment(helper.Jwt, "finishing", client.StartOptions{BusinessKey: "webhook"})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("receive", func(t *testing.T) {
+		deadline := time.Now().Add(30 * time.Second)
+		for time.Now().Before(deadline) {
+			mux.Lock()
+			count := len(received)
+			mux.Unlock()
+			if count >= 2 {
+				break
+			}
+			time.Sleep(time.Second)
+		}
+		mux.Lock()
+		defer mux.Unlock()
+		types := map[string]bool{}
+		for _, payload := range received {
+			if payload.WebhookId != webhook.Id || payload.Event.ProcessInstanceId != instance.Id || payload.Event.DeploymentId != "finishing" {
+				t.Errorf("%#v", payload)
+			}
+			types[payload.Event.Type] = true
+		}
+		if len(received) != 2 || !types[model.ProcessInstanceEventStarted] || !types[model.ProcessInstanceEventFinished] {
+			t.Errorf("%#v", received)
+		}
+	})
+
+	t.Run("delivery log", func(t *testing.T) {
+		deliveries, err, _ := wrapperClient.ListWebhookDeliveries(helper.Jwt, webhook.Id, 10, 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		attempts := 0
+		for _, delivery := range deliveries {
+			if delivery.State != model.WebhookDeliveryDelivered || delivery.DeliveredAt == nil {
+				t.Errorf("%#v", delivery)
+			}
+			attempts += delivery.Attempts
+		}
+		if len(deliveries) != 2 || attempts != 3 {
+			t.Errorf("%#v", deliveries)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err, _ := wrapperClient.DeleteWebhook(helper.Jwt, webhook.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err, code := wrapperClient.ListWebhookDeliveries(helper.Jwt, webhook.Id, 10, 0)
+		if err == nil || code != http.StatusNotFound {
+			t.Error(err, code)
+		}
+	})
+}
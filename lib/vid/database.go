@@ -29,6 +29,20 @@ var CreateVidTable = `CREATE TABLE IF NOT EXISTS VidRelation (
 );
 CREATE INDEX IF NOT EXISTS vid_index ON VidRelation (VirtualId);
 CREATE INDEX IF NOT EXISTS did_index ON VidRelation (DeploymentId);
+ALTER TABLE VidRelation ADD COLUMN IF NOT EXISTS Version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE VidRelation ADD COLUMN IF NOT EXISTS CreatedAt TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now();
+ALTER TABLE VidRelation ADD COLUMN IF NOT EXISTS Active BOOLEAN NOT NULL DEFAULT TRUE;
+UPDATE VidRelation SET Version = numbered.Version FROM (
+	SELECT ID, ROW_NUMBER() OVER (PARTITION BY VirtualId ORDER BY ID) AS Version FROM VidRelation
+	WHERE VirtualId IN (SELECT VirtualId FROM VidRelation GROUP BY VirtualId, Version HAVING COUNT(1) > 1)
+) AS numbered WHERE VidRelation.ID = numbered.ID;
+CREATE UNIQUE INDEX IF NOT EXISTS vid_version_index ON VidRelation (VirtualId, Version);
+CREATE TABLE IF NOT EXISTS IncidentHandling (
+	VirtualId			VARCHAR(255) PRIMARY KEY,
+	Restart				BOOLEAN NOT NULL,
+	Notify				BOOLEAN NOT NULL,
+	UpdatedAt			TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
 `
 
 type DbInterface interface {
@@ -19,6 +19,9 @@ package vid
 import (
 	"database/sql"
 	"errors"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/lib/pq"
 )
 
 func New(pgConn string) (vid *Vid, err error) {
@@ -31,9 +34,66 @@ type Vid struct {
 	db *sql.DB
 }
 
-//saves relation between vid (command.Id) and deploymentId
+//saves relation between vid (command.Id) and deploymentId as new active version of the vid
+//previous versions of the vid are kept as inactive versions
+//concurrent saves of the same vid are serialized with an advisory lock, so that versions are numbered consecutively
 func (this *Vid) SaveVidRelation(vid string, deploymentId string) (err error) {
-	_, err = this.db.Exec("INSERT INTO VidRelation (DeploymentId, VirtualId) VALUES ($1, $2);", deploymentId, vid)
+	tx, err := this.db.Begin()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1));`, "vid."+vid)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	_, err = tx.Exec("UPDATE VidRelation SET Active = FALSE WHERE VirtualId = $1;", vid)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO VidRelation (DeploymentId, VirtualId, Version, CreatedAt, Active) 
+		VALUES ($1, $2, (SELECT COALESCE(MAX(Version), 0) + 1 FROM VidRelation WHERE VirtualId = $2), now(), TRUE);`, deploymentId, vid)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+//returns all versions of the vid, newest first
+func (this *Vid) GetVersions(vid string) (result []model.DeploymentVersion, err error) {
+	result = []model.DeploymentVersion{}
+	rows, err := this.db.Query("SELECT DeploymentId, Version, CreatedAt, Active FROM VidRelation WHERE VirtualId = $1 ORDER BY Version DESC;", vid)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		version := model.DeploymentVersion{}
+		err = rows.Scan(&version.DeploymentId, &version.Version, &version.CreatedAt, &version.Active)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, version)
+	}
+	return result, rows.Err()
+}
+
+//returns the deploymentIds of inactive versions exceeding the count of versions to keep, oldest first
+func (this *Vid) GetExceedingVersions(vid string, keep int64) (deploymentIds []string, err error) {
+	rows, err := this.db.Query(`SELECT DeploymentId FROM (
+			SELECT DeploymentId, Version FROM VidRelation WHERE VirtualId = $1 AND Active = FALSE ORDER BY Version DESC OFFSET $2
+		) AS exceeding ORDER BY Version ASC;`, vid, keep)
+	if err != nil {
+		return nil, err
+	}
+	return rowsToStringList(rows)
+}
+
+//remove a single version
+func (this *Vid) RemoveVersion(deploymentId string) (err error) {
+	_, err = this.db.Exec("DELETE FROM VidRelation WHERE DeploymentId = $1;", deploymentId)
 	return err
 }
 
@@ -66,13 +126,57 @@ func (this *Vid) RemoveVidRelation(vid string, deploymentId string) (commit func
 		tx.Rollback()
 		return commit, rollback, err
 	}
+	_, err = tx.Exec("DELETE FROM IncidentHandling WHERE VirtualId = $1;", vid)
+	if err != nil {
+		tx.Rollback()
+		return commit, rollback, err
+	}
+	return tx.Commit, tx.Rollback, err
+}
+
+//saves the incident handling registered for the process-definitions of the vid
+func (this *Vid) SetIncidentHandling(vid string, handling model.IncidentHandling) (err error) {
+	_, err = this.db.Exec(`INSERT INTO IncidentHandling (VirtualId, Restart, Notify, UpdatedAt) VALUES ($1, $2, $3, now())
+		ON CONFLICT (VirtualId) DO UPDATE SET Restart = EXCLUDED.Restart, Notify = EXCLUDED.Notify, UpdatedAt = EXCLUDED.UpdatedAt;`, vid, handling.Restart, handling.Notify)
+	return err
+}
+
+func (this *Vid) GetIncidentHandling(vid string) (handling model.IncidentHandling, exists bool, err error) {
+	err = this.db.QueryRow("SELECT Restart, Notify FROM IncidentHandling WHERE VirtualId = $1;", vid).Scan(&handling.Restart, &handling.Notify)
+	if errors.Is(err, sql.ErrNoRows) {
+		return handling, false, nil
+	}
+	if err != nil {
+		return handling, false, err
+	}
+	return handling, true, nil
+}
+
+func (this *Vid) RemoveIncidentHandling(vid string) (err error) {
+	_, err = this.db.Exec("DELETE FROM IncidentHandling WHERE VirtualId = $1;", vid)
+	return err
+}
+
+//replaces deployment ids (old -> new) while keeping the related vids
+func (this *Vid) ReplaceDeploymentIds(replacements map[string]string) (commit func() error, rollback func() error, err error) {
+	tx, err := this.db.Begin()
+	if err != nil {
+		return commit, rollback, err
+	}
+	for oldDeploymentId, newDeploymentId := range replacements {
+		_, err = tx.Exec("UPDATE VidRelation SET DeploymentId = $1 WHERE DeploymentId = $2;", newDeploymentId, oldDeploymentId)
+		if err != nil {
+			tx.Rollback()
+			return commit, rollback, err
+		}
+	}
 	return tx.Commit, tx.Rollback, err
 }
 
-//returns deploymentId related to vid
+//returns deploymentId of the active version related to vid
 func (this *Vid) GetDeploymentId(vid string) (deploymentId string, exists bool, err error) {
 	exists = false
-	query := `SELECT DeploymentId FROM VidRelation WHERE VirtualId = $1;`
+	query := `SELECT DeploymentId FROM VidRelation WHERE VirtualId = $1 AND Active = TRUE;`
 	rows, err := this.db.Query(query, vid)
 	if err != nil {
 		return deploymentId, exists, err
@@ -119,6 +223,31 @@ func (this *Vid) GetVirtualId(deploymentId string) (vid string, exists bool, err
 	return arr[0], exists, err
 }
 
+//returns vid and active state of all known deploymentIds in a single query
+func (this *Vid) GetVirtualIdsAndStates(deploymentIds []string) (vids map[string]string, active map[string]bool, err error) {
+	vids = map[string]string{}
+	active = map[string]bool{}
+	if len(deploymentIds) == 0 {
+		return vids, active, nil
+	}
+	rows, err := this.db.Query(`SELECT DeploymentId, VirtualId, Active FROM VidRelation WHERE DeploymentId = ANY($1);`, pq.Array(deploymentIds))
+	if err != nil {
+		return vids, active, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var deploymentId, vid string
+		var isActive bool
+		err = rows.Scan(&deploymentId, &vid, &isActive)
+		if err != nil {
+			return vids, active, err
+		}
+		vids[deploymentId] = vid
+		active[deploymentId] = isActive
+	}
+	return vids, active, rows.Err()
+}
+
 /*
 //example for setVid in slices
 arr := Deployments{} // alias for []Deployment
@@ -149,7 +278,8 @@ type VidUpdateable interface {
 func (this *Vid) GetRelations() (byVid map[string]string, byDeploymentId map[string]string, err error) {
 	byVid = map[string]string{}
 	byDeploymentId = map[string]string{}
-	query := `SELECT DeploymentId, VirtualId FROM VidRelation;`
+	//active versions last, to map vids to their active deployment
+	query := `SELECT DeploymentId, VirtualId FROM VidRelation ORDER BY Active ASC;`
 	rows, err := this.db.Query(query)
 	defer rows.Close()
 	for rows.Next() {
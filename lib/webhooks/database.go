@@ -0,0 +1,64 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhooks
+
+import (
+	"database/sql"
+
+	_ "github.com/lib/pq"
+)
+
+var CreateWebhookTables = `CREATE TABLE IF NOT EXISTS Webhook (
+	ID					VARCHAR(255) PRIMARY KEY,
+	UserId				VARCHAR(255) NOT NULL,
+	Url					TEXT NOT NULL,
+	Secret				VARCHAR(255) NOT NULL,
+	Events				TEXT NOT NULL,
+	DeploymentId		VARCHAR(255) NOT NULL DEFAULT '',
+	DefinitionId		VARCHAR(255) NOT NULL DEFAULT '',
+	BusinessKey			TEXT NOT NULL DEFAULT '',
+	Enabled				BOOLEAN NOT NULL DEFAULT TRUE,
+	CreatedAt			TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+	UpdatedAt			TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS webhook_user_index ON Webhook (UserId);
+CREATE TABLE IF NOT EXISTS WebhookDelivery (
+	ID					VARCHAR(255) PRIMARY KEY,
+	WebhookId			VARCHAR(255) NOT NULL REFERENCES Webhook (ID) ON DELETE CASCADE,
+	EventKey			TEXT NOT NULL,
+	Event				TEXT NOT NULL,
+	State				VARCHAR(64) NOT NULL,
+	Attempts			INTEGER NOT NULL DEFAULT 0,
+	NextAttempt			TIMESTAMP WITH TIME ZONE,
+	LastStatusCode		INTEGER NOT NULL DEFAULT 0,
+	LastError			TEXT NOT NULL DEFAULT '',
+	CreatedAt			TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+	DeliveredAt			TIMESTAMP WITH TIME ZONE,
+	UNIQUE (WebhookId, EventKey)
+);
+CREATE INDEX IF NOT EXISTS webhook_delivery_due_index ON WebhookDelivery (State, NextAttempt);
+CREATE INDEX IF NOT EXISTS webhook_delivery_log_index ON WebhookDelivery (WebhookId, CreatedAt);
+`
+
+func InitDb(pgConn string) (db *sql.DB, err error) {
+	db, err = sql.Open("postgres", pgConn)
+	if err != nil {
+		return
+	}
+	_, err = db.Exec(CreateWebhookTables)
+	return db, err
+}
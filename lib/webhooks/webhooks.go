@@ -0,0 +1,219 @@
+/*
+ * Copyright 2025 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhooks
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/SENERGY-Platform/camunda-engine-wrapper/lib/model"
+	"github.com/google/uuid"
+)
+
+func New(pgConn string) (result *Webhooks, err error) {
+	result = &Webhooks{}
+	result.db, err = InitDb(pgConn)
+	return
+}
+
+// Webhooks stores webhooks and their deliveries; pending deliveries are the retry queue, finished deliveries the delivery log
+type Webhooks struct {
+	db *sql.DB
+}
+
+// Matches checks the event type and the deployment, definition and business key filters of the webhook
+func Matches(webhook model.Webhook, event model.ProcessInstanceEvent) bool {
+	return webhook.Enabled &&
+		webhook.UserId == event.UserId &&
+		(len(webhook.Events) == 0 || slices.Contains(webhook.Events, event.Type)) &&
+		(webhook.DeploymentId == "" || webhook.DeploymentId == event.DeploymentId) &&
+		(webhook.DefinitionId == "" || webhook.DefinitionId == event.ProcessDefinitionId) &&
+		(webhook.BusinessKey == "" || webhook.BusinessKey == event.BusinessKey)
+}
+
+const webhookColumns = `ID, UserId, Url, Secret, Events, DeploymentId, DefinitionId, BusinessKey, Enabled, CreatedAt, UpdatedAt`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWebhook(row scanner) (webhook model.Webhook, err error) {
+	var events string
+	err = row.Scan(&webhook.Id, &webhook.UserId, &webhook.Url, &webhook.Secret, &events, &webhook.DeploymentId, &webhook.DefinitionId, &webhook.BusinessKey, &webhook.Enabled, &webhook.CreatedAt, &webhook.UpdatedAt)
+	if err != nil {
+		return webhook, err
+	}
+	err = json.Unmarshal([]byte(events), &webhook.Events)
+	return webhook, err
+}
+
+func scanWebhooks(rows *sql.Rows, err error) (result []model.Webhook, _ error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result = []model.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, webhook)
+	}
+	return result, rows.Err()
+}
+
+func (this *Webhooks) Create(webhook model.Webhook) (result model.Webhook, err error) {
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return result, err
+	}
+	return scanWebhook(this.db.QueryRow(`INSERT INTO Webhook (ID, UserId, Url, Secret, Events, DeploymentId, DefinitionId, BusinessKey, Enabled) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING `+webhookColumns+`;`,
+		uuid.NewString(), webhook.UserId, webhook.Url, webhook.Secret, string(events), webhook.DeploymentId, webhook.DefinitionId, webhook.BusinessKey, webhook.Enabled))
+}
+
+// Update stores the webhook; an empty secret keeps the stored secret
+func (this *Webhooks) Update(webhook model.Webhook) (result model.Webhook, exists bool, err error) {
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return result, false, err
+	}
+	result, err = scanWebhook(this.db.QueryRow(`UPDATE Webhook SET Url = $2, Secret = COALESCE(NULLIF($3, ''), Secret), Events = $4, DeploymentId = $5, DefinitionId = $6, BusinessKey = $7, Enabled = $8, UpdatedAt = now() WHERE ID = $1 RETURNING `+webhookColumns+`;`,
+		webhook.Id, webhook.Url, webhook.Secret, string(events), webhook.DeploymentId, webhook.DefinitionId, webhook.BusinessKey, webhook.Enabled))
+	if errors.Is(err, sql.ErrNoRows) {
+		return result, false, nil
+	}
+	return result, err == nil, err
+}
+
+func (this *Webhooks) Get(id string) (result model.Webhook, exists bool, err error) {
+	result, err = scanWebhook(this.db.QueryRow(`SELECT `+webhookColumns+` FROM Webhook WHERE ID = $1;`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return result, false, nil
+	}
+	return result, err == nil, err
+}
+
+// List returns the webhooks of the user ordered by creation
+func (this *Webhooks) List(userId string) (result []model.Webhook, err error) {
+	return scanWebhooks(this.db.Query(`SELECT `+webhookColumns+` FROM Webhook WHERE UserId = $1 ORDER BY CreatedAt;`, userId))
+}
+
+// ListEnabled returns the enabled webhooks of the user
+func (this *Webhooks) ListEnabled(userId string) (result []model.Webhook, err error) {
+	return scanWebhooks(this.db.Query(`SELECT `+webhookColumns+` FROM Webhook WHERE UserId = $1 AND Enabled = TRUE;`, userId))
+}
+
+// ListUsers returns the users with enabled webhooks
+func (this *Webhooks) ListUsers() (result []string, err error) {
+	rows, err := this.db.Query(`SELECT DISTINCT UserId FROM Webhook WHERE Enabled = TRUE ORDER BY UserId;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var userId string
+		err = rows.Scan(&userId)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, userId)
+	}
+	return result, rows.Err()
+}
+
+// Remove removes the webhook with its deliveries
+func (this *Webhooks) Remove(id string) (err error) {
+	_, err = this.db.Exec(`DELETE FROM Webhook WHERE ID = $1;`, id)
+	return err
+}
+
+const deliveryColumns = `ID, WebhookId, Event, State, Attempts, NextAttempt, LastStatusCode, LastError, CreatedAt, DeliveredAt`
+
+func scanDeliveries(rows *sql.Rows, err error) (result []model.WebhookDelivery, _ error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result = []model.WebhookDelivery{}
+	for rows.Next() {
+		delivery := model.WebhookDelivery{}
+		var event string
+		var nextAttempt, deliveredAt sql.NullTime
+		err = rows.Scan(&delivery.Id, &delivery.WebhookId, &event, &delivery.State, &delivery.Attempts, &nextAttempt, &delivery.LastStatusCode, &delivery.LastError, &delivery.CreatedAt, &deliveredAt)
+		if err != nil {
+			return nil, err
+		}
+		if nextAttempt.Valid {
+			delivery.NextAttempt = &nextAttempt.Time
+		}
+		if deliveredAt.Valid {
+			delivery.DeliveredAt = &deliveredAt.Time
+		}
+		err = json.Unmarshal([]byte(event), &delivery.Event)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, delivery)
+	}
+	return result, rows.Err()
+}
+
+// Enqueue adds a pending delivery of the event; events already enqueued for the webhook (e.g. polled by another wrapper instance) are ignored
+func (this *Webhooks) Enqueue(webhookId string, eventKey string, event model.ProcessInstanceEvent) (err error) {
+	msg, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = this.db.Exec(`INSERT INTO WebhookDelivery (ID, WebhookId, EventKey, Event, State, NextAttempt) VALUES ($1, $2, $3, $4, $5, now()) ON CONFLICT (WebhookId, EventKey) DO NOTHING;`,
+		uuid.NewString(), webhookId, eventKey, string(msg), model.WebhookDeliveryPending)
+	return err
+}
+
+// ClaimDue returns up to limit pending deliveries with a NextAttempt before now and moves their NextAttempt to now + lease,
+// so that other wrapper instances do not send them while they are delivered
+func (this *Webhooks) ClaimDue(now time.Time, limit int, lease time.Duration) (result []model.WebhookDelivery, err error) {
+	return scanDeliveries(this.db.Query(`UPDATE WebhookDelivery SET NextAttempt = $2 WHERE ID IN (
+		SELECT ID FROM WebhookDelivery WHERE State = $3 AND NextAttempt <= $1 ORDER BY NextAttempt LIMIT $4 FOR UPDATE SKIP LOCKED
+	) RETURNING `+deliveryColumns+`;`, now, now.Add(lease), model.WebhookDeliveryPending, limit))
+}
+
+// SetDeliveryResult stores the result of a delivery attempt; next is the time of the next attempt of pending deliveries
+func (this *Webhooks) SetDeliveryResult(id string, state string, statusCode int, errMsg string, next *time.Time) (err error) {
+	var deliveredAt *time.Time
+	if state == model.WebhookDeliveryDelivered {
+		now := time.Now()
+		deliveredAt = &now
+	}
+	_, err = this.db.Exec(`UPDATE WebhookDelivery SET State = $2, Attempts = Attempts + 1, LastStatusCode = $3, LastError = $4, NextAttempt = $5, DeliveredAt = $6 WHERE ID = $1;`,
+		id, state, statusCode, errMsg, next, deliveredAt)
+	return err
+}
+
+// ListDeliveries returns the deliveries of the webhook, newest first
+func (this *Webhooks) ListDeliveries(webhookId string, limit int, offset int) (result []model.WebhookDelivery, err error) {
+	return scanDeliveries(this.db.Query(`SELECT `+deliveryColumns+` FROM WebhookDelivery WHERE WebhookId = $1 ORDER BY CreatedAt DESC LIMIT $2 OFFSET $3;`, webhookId, limit, offset))
+}
+
+// RemoveFinishedDeliveries removes delivered and failed deliveries created before the given time
+func (this *Webhooks) RemoveFinishedDeliveries(before time.Time) (err error) {
+	_, err = this.db.Exec(`DELETE FROM WebhookDelivery WHERE State <> $1 AND CreatedAt < $2;`, model.WebhookDeliveryPending, before)
+	return err
+}